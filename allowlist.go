@@ -0,0 +1,73 @@
+package swearfilter
+
+import (
+	"sort"
+	"strings"
+)
+
+// AddAllowed adds words to the allowlist: a word from the wordlist found entirely inside an allowed word (ex: "ass"
+// in "class", "cunt" in "Scunthorpe") doesn't trip the filter there
+//
+// Allowed words are compared against the normalized message, so they also cover their leet and spaced out forms.
+// Unlike entries with ActionAllow, which let through every message they're found in, the allowlist only suppresses
+// the matches it contains.
+func (filter *SwearFilter) AddAllowed(words ...string) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	if filter.allowed == nil {
+		filter.allowed = make(map[string]struct{})
+	}
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			filter.allowed[word] = struct{}{}
+		}
+	}
+}
+
+// DeleteAllowed deletes words from the allowlist
+func (filter *SwearFilter) DeleteAllowed(words ...string) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	for _, word := range words {
+		delete(filter.allowed, strings.ToLower(strings.TrimSpace(word)))
+	}
+}
+
+// AllowedWords returns the allowlist, sorted
+func (filter *SwearFilter) AllowedWords() (words []string) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	for word := range filter.allowed {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// matchRule returns how swear may match: outside of its own exceptions and the allowlist, and only on its own if
+// the filter or the word requires word boundaries; the filter's lock must be held
+func (filter *SwearFilter) matchRule(swear string) matchRule {
+	entry := filter.entries[swear]
+	rule := matchRule{
+		exceptions: entry.Exceptions,
+		boundaries: filter.RequireWordBoundaries || entry.RequireWordBoundaries,
+		distance:   filter.editDistance(swear),
+	}
+	if filter.PhoneticBlocking {
+		rule.phonetic = filter.phoneticCode(swear)
+	}
+	if len(filter.allowed) == 0 {
+		return rule
+	}
+	rule.exceptions = make([]string, 0, len(entry.Exceptions)+len(filter.allowed))
+	rule.exceptions = append(rule.exceptions, entry.Exceptions...)
+	for word := range filter.allowed {
+		if len(word) > len(swear) && strings.Contains(word, swear) {
+			rule.exceptions = append(rule.exceptions, word)
+		}
+	}
+	return rule
+}
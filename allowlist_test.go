@@ -0,0 +1,46 @@
+package swearfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllowlist(t *testing.T) {
+	filter := NewSwearFilter(true, "ass", "cunt")
+	filter.AddAllowed("class", "Assistant", "Scunthorpe", "  ")
+
+	if words := filter.AllowedWords(); !reflect.DeepEqual(words, []string{"assistant", "class", "scunthorpe"}) {
+		t.Errorf("got allowed words %v, want them lowercased and sorted", words)
+	}
+
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"first class", 0},
+		{"my assistant", 0},
+		{"Scunthorpe United", 0},
+		{"cl4ss act", 0},
+		{"s c u n t h o r p e", 0},
+		{"class, you ass", 1},
+		{"cunt", 1},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if len(trippers) != tt.expected {
+			t.Errorf("got trippers %v for %q, want %d", trippers, tt.input, tt.expected)
+		}
+	}
+
+	if censored, _, _ := filter.Censor("class, you ass"); censored != "class, you ***" {
+		t.Errorf("got %q, want only the match outside the allowed word censored", censored)
+	}
+
+	filter.DeleteAllowed("CLASS")
+	if trippers, _ := filter.Check("first class"); len(trippers) != 1 {
+		t.Errorf("got trippers %v after deleting the allowed word, want ass", trippers)
+	}
+}
@@ -0,0 +1,62 @@
+package swearfilter
+
+import (
+	"runtime"
+	"sync"
+)
+
+// asyncPool runs CheckAsync jobs on a bounded number of workers that exit as soon as the queue is drained
+type asyncPool struct {
+	mutex   sync.Mutex
+	queue   []asyncJob
+	running int
+}
+
+type asyncJob struct {
+	msg    string
+	result chan Result
+}
+
+// CheckAsync inspects msg in the background and delivers the outcome on the returned channel, which receives exactly
+// one Result and is never closed
+//
+// At most AsyncWorkers checks run at once; further calls are queued. Any error is reported in Result.Err.
+func (filter *SwearFilter) CheckAsync(msg string) <-chan Result {
+	job := asyncJob{msg: msg, result: make(chan Result, 1)}
+
+	workers := filter.AsyncWorkers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	pool := &filter.async
+	pool.mutex.Lock()
+	pool.queue = append(pool.queue, job)
+	if pool.running < workers {
+		pool.running++
+		go filter.asyncWorker()
+	}
+	pool.mutex.Unlock()
+
+	return job.result
+}
+
+func (filter *SwearFilter) asyncWorker() {
+	pool := &filter.async
+	for {
+		pool.mutex.Lock()
+		if len(pool.queue) == 0 {
+			pool.running--
+			pool.mutex.Unlock()
+			return
+		}
+		job := pool.queue[0]
+		pool.queue[0] = asyncJob{}
+		pool.queue = pool.queue[1:]
+		pool.mutex.Unlock()
+
+		result, err := filter.Inspect(job.msg)
+		result.Err = err
+		job.result <- result
+	}
+}
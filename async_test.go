@@ -0,0 +1,36 @@
+package swearfilter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCheckAsync(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.AsyncWorkers = 2
+
+	results := make([]<-chan Result, 50)
+	for i := range results {
+		msg := fmt.Sprintf("message %d", i)
+		if i%2 == 0 {
+			msg = "fuck " + msg
+		}
+		results[i] = filter.CheckAsync(msg)
+	}
+	for i, pending := range results {
+		result := <-pending
+		if result.Err != nil {
+			t.Errorf("CheckAsync failed: %v", result.Err)
+		}
+		if tripped := len(result.Words) > 0; tripped != (i%2 == 0) {
+			t.Errorf("got words %v for message %d, want tripped %t", result.Words, i, i%2 == 0)
+		}
+	}
+
+	filter.async.mutex.Lock()
+	running, queued := filter.async.running, len(filter.async.queue)
+	filter.async.mutex.Unlock()
+	if running > 2 || queued != 0 {
+		t.Errorf("got %d workers and %d queued jobs after draining, want at most %d and none", running, queued, 2)
+	}
+}
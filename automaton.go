@@ -0,0 +1,197 @@
+package swearfilter
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// automaton is an Aho-Corasick automaton over the wordlist, finding every word in a message in a single pass
+type automaton struct {
+	words []string
+	nodes []automatonNode
+	root  [256]int32 //Node every byte leads to from the root, most of a message never getting past it
+}
+
+type automatonNode struct {
+	next map[byte]int32
+	fail int32 //Node of the longest proper suffix of this node's path that is also in the trie
+	word int32 //Index of the word ending at this node, -1 if none
+	dict int32 //Nearest node down the fail links where a word ends, -1 if none
+}
+
+// newAutomaton compiles an automaton matching the given words
+func newAutomaton(words []string) *automaton {
+	sort.Strings(words)
+	a := &automaton{words: words, nodes: []automatonNode{{fail: 0, word: -1, dict: -1}}}
+	for i, word := range words {
+		node := int32(0)
+		for j := 0; j < len(word); j++ {
+			next, ok := a.nodes[node].next[word[j]]
+			if !ok {
+				if a.nodes[node].next == nil {
+					a.nodes[node].next = make(map[byte]int32)
+				}
+				next = int32(len(a.nodes))
+				a.nodes = append(a.nodes, automatonNode{word: -1, dict: -1})
+				a.nodes[node].next[word[j]] = next
+			}
+			node = next
+		}
+		a.nodes[node].word = int32(i)
+	}
+
+	//Link every node to its longest suffix in the trie, breadth first so suffixes are always linked before
+	queue := make([]int32, 0, len(a.nodes))
+	for _, child := range a.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for c, child := range a.nodes[node].next {
+			fail := a.nodes[node].fail
+			for {
+				if next, ok := a.nodes[fail].next[c]; ok && next != child {
+					a.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					a.nodes[child].fail = 0
+					break
+				}
+				fail = a.nodes[fail].fail
+			}
+			if target := a.nodes[child].fail; a.nodes[target].word >= 0 {
+				a.nodes[child].dict = target
+			} else {
+				a.nodes[child].dict = a.nodes[target].dict
+			}
+			queue = append(queue, child)
+		}
+	}
+	for c, child := range a.nodes[0].next {
+		a.root[c] = child
+	}
+	return a
+}
+
+// find calls fn with the index of every word found in text, as many times as it is found
+func (a *automaton) find(text string, fn func(word int)) {
+	node := int32(0)
+	for i := 0; i < len(text); i++ {
+		for {
+			if node == 0 {
+				node = a.root[text[i]]
+				break
+			}
+			if next, ok := a.nodes[node].next[text[i]]; ok {
+				node = next
+				break
+			}
+			node = a.nodes[node].fail
+		}
+		for out := node; out >= 0; out = a.nodes[out].dict {
+			if word := a.nodes[out].word; word >= 0 {
+				fn(int(word))
+			}
+		}
+	}
+}
+
+// Compile rebuilds the matcher over the wordlist right away instead of on the next check after it changed, so that
+// check doesn't pay for it
+//
+// Changes made through the filter's methods are picked up automatically, as is assigning BadWords a new map; changes
+// made directly to the entries of BadWords are only picked up by Compile, or when they change the number of words.
+func (filter *SwearFilter) Compile() {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	filter.matcher = nil
+	filter.compiled()
+}
+
+// compiled returns the matcher over the wordlist, building it if the wordlist changed since, the filter's lock must be
+// held for reading at least
+func (filter *SwearFilter) compiled() *compiledWords {
+	filter.matcherMutex.Lock()
+	defer filter.matcherMutex.Unlock()
+
+	identity := reflect.ValueOf(filter.BadWords).Pointer()
+	if filter.matcher == nil || filter.matcher.size != len(filter.BadWords) || filter.matcher.identity != identity ||
+		filter.matcher.algorithm != filter.Phonetic {
+		words := make([]string, 0, len(filter.BadWords))
+		for word := range filter.BadWords {
+			if word != " " && word != "" {
+				words = append(words, word)
+			}
+		}
+		filter.matcher = &compiledWords{
+			automaton: newAutomaton(words), size: len(filter.BadWords), identity: identity, algorithm: filter.Phonetic,
+		}
+		for i, word := range filter.matcher.automaton.words {
+			if code := filter.phoneticCode(word); code != "" {
+				if filter.matcher.phonetic == nil {
+					filter.matcher.phonetic = make(map[string][]int)
+				}
+				filter.matcher.phonetic[code] = append(filter.matcher.phonetic[code], i)
+			}
+			distance := filter.entries[word].MaxEditDistance
+			if distance >= 0 && utf8.RuneCountInString(word) >= fuzzyMinLength {
+				filter.matcher.fuzzy = append(filter.matcher.fuzzy, i)
+			}
+			if distance > 0 {
+				filter.matcher.fuzzyWords = true
+			}
+		}
+	}
+	return filter.matcher
+}
+
+// compiledWords is the matcher built over the wordlist when it had size words
+type compiledWords struct {
+	automaton *automaton
+	size      int
+	identity  uintptr //Address of the BadWords map it was built from, so assigning another one is noticed
+
+	fuzzy      []int //Words long enough to be matched fuzzily, unless the filter and the word both leave it off
+	fuzzyWords bool  //Whether any word turns fuzzy matching on for itself
+
+	algorithm PhoneticAlgorithm //Phonetic algorithm the codes were computed with
+	phonetic  map[string][]int  //Words by their phonetic code, for those matched phonetically
+}
+
+// candidates returns the words found anywhere in the normalized message, and in it with its spaces removed when the
+// spaced bypass is on, along with the words that could be matched fuzzily, in sorted order
+func (filter *SwearFilter) candidates(message string, opts scanOptions) (words []string) {
+	compiled := filter.compiled()
+	a := compiled.automaton
+	found := make([]bool, len(a.words))
+	mark := func(word int) {
+		found[word] = true
+	}
+	a.find(message, mark)
+	if filter.EnableSpacedBypass && !opts.disableSpacedBypass {
+		a.find(strings.Replace(message, " ", "", -1), mark)
+	}
+	if filter.MaxEditDistance > 0 || compiled.fuzzyWords {
+		for _, word := range compiled.fuzzy {
+			found[word] = true
+		}
+	}
+	if len(compiled.phonetic) > 0 && filter.PhoneticBlocking {
+		for _, span := range wordSpans(message) {
+			for _, word := range compiled.phonetic[filter.Phonetic.Encode(message[span[0]:span[1]])] {
+				found[word] = true
+			}
+		}
+	}
+	for i, ok := range found {
+		if ok {
+			words = append(words, a.words[i])
+		}
+	}
+	return words
+}
@@ -0,0 +1,58 @@
+package swearfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAutomaton(t *testing.T) {
+	words := []string{"he", "she", "his", "hers", "ass", "assassin", "sin", "s"}
+	a := newAutomaton(append([]string(nil), words...))
+
+	for _, text := range []string{"ushers", "assassination", "hehehe", "his sins", "nothing", ""} {
+		counts := make(map[string]int)
+		a.find(text, func(word int) {
+			counts[a.words[word]]++
+		})
+		expected := make(map[string]int)
+		for _, word := range words {
+			if n := strings.Count(text, word); n > 0 {
+				expected[word] = n
+			}
+		}
+		if !reflect.DeepEqual(counts, expected) {
+			t.Errorf("got %v in %q, want %v", counts, text, expected)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	filter := NewSwearFilter(true, "fuck")
+	if trippers, _ := filter.Check("f u c k"); len(trippers) != 1 {
+		t.Errorf("got trippers %v, want the spaced word", trippers)
+	}
+
+	filter.Add("shit")
+	if trippers, _ := filter.Check("shit"); len(trippers) != 1 {
+		t.Errorf("got trippers %v after Add, want the new word", trippers)
+	}
+	filter.Delete("fuck")
+	if trippers, _ := filter.Check("fuck"); len(trippers) != 0 {
+		t.Errorf("got trippers %v after Delete, want none", trippers)
+	}
+
+	//Swapping a word directly keeps the count, so only Compile notices
+	delete(filter.BadWords, "shit")
+	filter.BadWords["damn"] = struct{}{}
+	filter.Compile()
+	if trippers, _ := filter.Check("damn shit"); len(trippers) != 1 || trippers[0] != "damn" {
+		t.Errorf("got trippers %v after Compile, want the directly added word", trippers)
+	}
+
+	//Assigning a new map of the same size is noticed without Compile
+	filter.BadWords = map[string]struct{}{"shit": {}}
+	if trippers, _ := filter.Check("shit"); len(trippers) != 1 || trippers[0] != "shit" {
+		t.Errorf("got trippers %v after replacing BadWords, want the new word", trippers)
+	}
+}
@@ -0,0 +1,99 @@
+package swearfilter
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// isWordRune reports whether r can be part of a word: letters, marks and digits in any script
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+// wordSpans returns the byte ranges of the words of message, the runs of word runes in it
+func wordSpans(message string) (spans [][2]int) {
+	for start := 0; start < len(message); {
+		r, size := utf8.DecodeRuneInString(message[start:])
+		if !isWordRune(r) {
+			start += size
+			continue
+		}
+		end := start
+		for end < len(message) {
+			r, size := utf8.DecodeRuneInString(message[end:])
+			if !isWordRune(r) {
+				break
+			}
+			end += size
+		}
+		spans = append(spans, [2]int{start, end})
+		start = end
+	}
+	return spans
+}
+
+// wordBoundary reports whether the text from start to end of message stands on its own, with no word character
+// touching it on either side
+func wordBoundary(message string, start, end int) bool {
+	if before, size := utf8.DecodeLastRuneInString(message[:start]); size > 0 && isWordRune(before) {
+		return false
+	}
+	if after, size := utf8.DecodeRuneInString(message[end:]); size > 0 && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+// spacedOccurrences returns the byte offsets swear occurs at in message once its spaces are removed, as the spaced
+// bypass sees it
+//
+// When the rule requires boundaries, a spaced out word must not be touching a longer word, nor be continued by more
+// spaced out characters: "h e l l" matches hell, but "h e l l o" doesn't.
+func spacedOccurrences(message, swear string, rule matchRule) (offsets []int) {
+	nospace := newMappedText(message).replaceAll(" ", "")
+	for _, i := range occurrences(nospace.text, swear, matchRule{exceptions: rule.exceptions}) {
+		start, end := nospace.span(i, i+len(swear))
+		if !rule.boundaries || spacedBoundary(message, start, end, rule) {
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
+
+// spacedBoundary reports whether the spaced out text from start to end of message is a whole word
+func spacedBoundary(message string, start, end int, rule matchRule) bool {
+	if !rule.boundary(message, start, end) {
+		return false
+	}
+
+	//Skip the spaces around the text and make sure it's not continued by a lone character
+	i := start
+	for i > 0 && message[i-1] == ' ' {
+		i--
+	}
+	if i < start && loneWordRune(message, i, true) {
+		return false
+	}
+	j := end
+	for j < len(message) && message[j] == ' ' {
+		j++
+	}
+	return !(j > end && loneWordRune(message, j, false))
+}
+
+// loneWordRune reports whether the word character right before (or right after) offset i of message stands alone
+// between spaces, the way spaced out words are written
+func loneWordRune(message string, i int, before bool) bool {
+	var r rune
+	var size int
+	if before {
+		r, size = utf8.DecodeLastRuneInString(message[:i])
+		i -= size
+	} else {
+		r, size = utf8.DecodeRuneInString(message[i:])
+	}
+	if size == 0 || !isWordRune(r) {
+		return false
+	}
+	return wordBoundary(message, i, i+size)
+}
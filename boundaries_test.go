@@ -0,0 +1,49 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestRequireWordBoundaries(t *testing.T) {
+	filter := NewSwearFilter(true, "hell", "ass")
+	filter.RequireWordBoundaries = true
+
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"hell", 1},
+		{"go to hell!", 1},
+		{"hello", 0},
+		{"shell", 0},
+		{"HELL.", 1},
+		{"hellé", 0},
+		{"hell2", 0},
+		{"h e l l", 1},
+		{"h e l l no", 1},
+		{"h e l l o", 0},
+		{"s h e l l", 0},
+		{"sh ell", 0},
+		{"a s s", 1},
+		{"h3ll yeah", 1},
+		{"адhell", 0},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if len(trippers) != tt.expected {
+			t.Errorf("got trippers %v for %q, want %d", trippers, tt.input, tt.expected)
+		}
+	}
+
+	filter.RequireWordBoundaries = false
+	filter.AddWithOptions(WordOptions{RequireWordBoundaries: true}, "hell")
+	if trippers, _ := filter.Check("hello, bass"); len(trippers) != 1 || trippers[0] != "ass" {
+		t.Errorf("got trippers %v, want only the word without boundaries", trippers)
+	}
+	if censored, _, _ := filter.Censor("hello hell"); censored != "hello ****" {
+		t.Errorf("got %q, want only the whole word censored", censored)
+	}
+}
@@ -0,0 +1,32 @@
+package swearfilter
+
+import (
+	"hash/fnv"
+)
+
+// CanaryStats counts how often a word under a partial rollout was found
+type CanaryStats struct {
+	WouldBlock uint64 //Checks the word was found in, whether or not it was enforced
+	Blocked    uint64 //Checks the word was found in and enforced
+}
+
+type canaryHit struct {
+	word    string
+	blocked bool
+}
+
+// CheckFor works like Check, but buckets words with a RolloutPercent by key instead of by the message itself
+//
+// Passing a stable key such as a user ID makes a partially rolled out word either always or never apply to that user.
+func (filter *SwearFilter) CheckFor(key, msg string) (trippedWords []string, err error) {
+	return filter.check(msg, scanOptions{key: key})
+}
+
+// inRollout deterministically reports whether key falls within the first percent buckets for word
+func inRollout(word, key string, percent int) bool {
+	h := fnv.New32a()
+	h.Write([]byte(word))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return int(h.Sum32()%100) < percent
+}
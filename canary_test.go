@@ -0,0 +1,38 @@
+package swearfilter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCanary(t *testing.T) {
+	filter := NewSwearFilter(false)
+	filter.AddWithOptions(WordOptions{RolloutPercent: 30}, "heck")
+
+	blocked := 0
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("user%d", i)
+		first, err := filter.CheckFor(key, "what the heck")
+		if err != nil {
+			t.Fatalf("CheckFor failed: %v", err)
+		}
+		second, _ := filter.CheckFor(key, "oh heck")
+		if len(first) != len(second) {
+			t.Errorf("got %v then %v for key %s, want the same verdict", first, second, key)
+		}
+		if len(first) > 0 {
+			blocked++
+		}
+	}
+	if blocked < 200 || blocked > 400 {
+		t.Errorf("got %d of 1000 keys blocked, want roughly %d", blocked, 300)
+	}
+
+	counts := filter.Stats().Canary["heck"]
+	if counts.WouldBlock != 2000 {
+		t.Errorf("got would-block count %d, want %d", counts.WouldBlock, 2000)
+	}
+	if counts.Blocked != uint64(blocked*2) {
+		t.Errorf("got blocked count %d, want %d", counts.Blocked, blocked*2)
+	}
+}
@@ -0,0 +1,79 @@
+package swearfilter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Censor checks msg and returns it with every tripped word replaced, along with the words that tripped
+//
+// Replacements are made in msg as it was given, so the censored message keeps its casing, accents and spacing.
+// Every character of a tripped word is replaced with CensorRune, except for the whitespace inside a word caught
+// by the spaced bypass, unless CensorFunc is set. Co-occurrence rules trip the message without censoring anything.
+func (filter *SwearFilter) Censor(msg string) (censored string, trippedWords []string, err error) {
+	var mapped mappedText
+	trippedWords, err = filter.check(msg, scanOptions{key: msg, mapped: &mapped})
+	if err != nil {
+		return "", nil, err
+	}
+
+	filter.mutex.RLock()
+	spans := filter.wordSpans(msg, mapped, trippedWords, scanOptions{})
+	replace := filter.replacement()
+	filter.mutex.RUnlock()
+
+	return censorSpans(msg, 0, spans, replace), trippedWords, nil
+}
+
+// replacement returns what tripped words are replaced with, CensorFunc or a mask of CensorRune, the filter's lock must
+// be held
+func (filter *SwearFilter) replacement() func(word string) string {
+	if filter.CensorFunc != nil {
+		return filter.CensorFunc
+	}
+	mask := filter.CensorRune
+	if mask == 0 {
+		mask = '*'
+	}
+	return func(word string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return r
+			}
+			return mask
+		}, word)
+	}
+}
+
+// censorSpans returns text with the parts of it covered by the sorted, merged spans replaced, text starting at offset
+// in the message the spans are ranges of
+func censorSpans(text string, offset int, spans [][2]int, replace func(word string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		start, end := span[0]-offset, span[1]-offset
+		if start < last {
+			start = last
+		}
+		if end > len(text) {
+			end = len(text)
+		}
+		if start >= end {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(replace(text[start:end]))
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// wordSpans returns the merged ranges of the original message the given words were found at, the filter's lock must
+// be held
+func (filter *SwearFilter) wordSpans(msg string, message mappedText, words []string, opts scanOptions) (spans [][2]int) {
+	for _, match := range filter.findWords(msg, message, words, opts) {
+		spans = append(spans, [2]int{match.Start, match.End})
+	}
+	return mergeSpans(spans)
+}
@@ -0,0 +1,50 @@
+package swearfilter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCensor(t *testing.T) {
+	filter := NewSwearFilter(true, "fuck", "shit", "hell")
+	filter.AddWithOptions(WordOptions{Shadow: true}, "damn")
+	filter.AddWithOptions(WordOptions{Exceptions: []string{"assign"}}, "ass")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"what the fuck", "what the ****"},
+		{"What The FUCK", "What The ****"},
+		{"fück this", "**** this"},
+		{"sh1t happens", "**** happens"},
+		{"ph0ck", "ph0ck"},
+		{"phuck off", "***** off"},
+		{"f u c k you", "* * * * you"},
+		{"assign it, you ass", "assign it, you ***"},
+		{"damn it", "damn it"},
+		{"hellfuck", "********"},
+		{"xn--fck-hoa.example", "***********.example"},
+		{"clean message", "clean message"},
+	}
+	for _, tt := range tests {
+		censored, _, err := filter.Censor(tt.input)
+		if err != nil {
+			t.Fatalf("Censor(%q) failed: %v", tt.input, err)
+		}
+		if censored != tt.expected {
+			t.Errorf("got %q for %q, want %q", censored, tt.input, tt.expected)
+		}
+	}
+
+	filter.CensorRune = '#'
+	if censored, words, _ := filter.Censor("oh shit"); censored != "oh ####" || len(words) != 1 || words[0] != "shit" {
+		t.Errorf("got %q, %v with a custom rune, want %q and the tripped word", censored, words, "oh ####")
+	}
+	filter.CensorFunc = func(word string) string {
+		return word[:1] + strings.Repeat("-", len(word)-1)
+	}
+	if censored, _, _ := filter.Censor("Fuck that"); censored != "F--- that" {
+		t.Errorf("got %q with a callback, want %q", censored, "F--- that")
+	}
+}
@@ -0,0 +1,103 @@
+package swearfilter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/japanese"
+	xunicode "golang.org/x/text/encoding/unicode"
+)
+
+// Names of the charsets DecodeText detects
+const (
+	CharsetUTF8        = "utf-8"
+	CharsetUTF16LE     = "utf-16le"
+	CharsetUTF16BE     = "utf-16be"
+	CharsetShiftJIS    = "shift_jis"
+	CharsetWindows1252 = "windows-1252"
+	CharsetISO88591    = "iso-8859-1"
+)
+
+// DecodeText converts raw bytes of unknown encoding to UTF-8 and reports the charset it decoded them as
+//
+// Byte order marks are honored, and valid UTF-8 is returned as is. Otherwise the bytes are treated as Shift-JIS if
+// they decode cleanly and contain kana, as ISO-8859-1 if they use bytes Windows-1252 leaves undefined, and as
+// Windows-1252 in every other case, which matches how browsers treat unlabeled legacy text.
+func DecodeText(raw []byte) (text, charset string, err error) {
+	switch {
+	case bytes.HasPrefix(raw, []byte{0xEF, 0xBB, 0xBF}):
+		return string(raw[3:]), CharsetUTF8, nil
+	case bytes.HasPrefix(raw, []byte{0xFF, 0xFE}):
+		text, err = decodeWith(xunicode.UTF16(xunicode.LittleEndian, xunicode.UseBOM), raw)
+		return text, CharsetUTF16LE, err
+	case bytes.HasPrefix(raw, []byte{0xFE, 0xFF}):
+		text, err = decodeWith(xunicode.UTF16(xunicode.BigEndian, xunicode.UseBOM), raw)
+		return text, CharsetUTF16BE, err
+	case utf8.Valid(raw):
+		return string(raw), CharsetUTF8, nil
+	}
+
+	if text, err = decodeWith(japanese.ShiftJIS, raw); err == nil && likelyJapanese(text) {
+		return text, CharsetShiftJIS, nil
+	}
+	for _, c := range raw {
+		if c == 0x81 || c == 0x8D || c == 0x8F || c == 0x90 || c == 0x9D {
+			text, err = decodeWith(charmap.ISO8859_1, raw)
+			return text, CharsetISO88591, err
+		}
+	}
+	text, err = decodeWith(charmap.Windows1252, raw)
+	return text, CharsetWindows1252, err
+}
+
+// DecodeTextCharset converts raw bytes in the named charset to UTF-8, accepting any label the WHATWG Encoding
+// standard does (ex: "latin1", "sjis", "cp1252"); an empty charset falls back to DecodeText's detection
+func DecodeTextCharset(raw []byte, charset string) (string, error) {
+	if strings.TrimSpace(charset) == "" {
+		text, _, err := DecodeText(raw)
+		return text, err
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return "", fmt.Errorf("swearfilter: unsupported charset %q: %v", charset, err)
+	}
+	return decodeWith(enc, raw)
+}
+
+// CheckBytes decodes raw with DecodeText and checks the resulting text
+func (filter *SwearFilter) CheckBytes(raw []byte) (trippedWords []string, err error) {
+	text, _, err := DecodeText(raw)
+	if err != nil {
+		return nil, err
+	}
+	return filter.Check(text)
+}
+
+func decodeWith(enc encoding.Encoding, raw []byte) (string, error) {
+	decoded, err := enc.NewDecoder().Bytes(raw)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// likelyJapanese reports whether text decoded without replacement characters and contains kana, which real
+// Japanese text almost always does and Latin text misread as Shift-JIS almost never does
+func likelyJapanese(text string) bool {
+	kana := false
+	for _, r := range text {
+		if r == utf8.RuneError {
+			return false
+		}
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana) && (r < 0xFF61 || r > 0xFF9F) {
+			kana = true
+		}
+	}
+	return kana
+}
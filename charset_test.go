@@ -0,0 +1,54 @@
+package swearfilter
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestDecodeText(t *testing.T) {
+	shiftJIS, _ := japanese.ShiftJIS.NewEncoder().Bytes([]byte("これはテストです"))
+
+	tests := []struct {
+		name     string
+		input    []byte
+		text     string
+		expected string
+	}{
+		{"utf-8", []byte("fûçk"), "fûçk", CharsetUTF8},
+		{"utf-8 bom", []byte("\xEF\xBB\xBFhi"), "hi", CharsetUTF8},
+		{"utf-16le bom", []byte{0xFF, 0xFE, 'h', 0, 'i', 0}, "hi", CharsetUTF16LE},
+		{"windows-1252", []byte("\x93f\xFB\xE7k\x94 \xE9l\xE8ve"), "“fûçk” élève", CharsetWindows1252},
+		{"iso-8859-1", []byte("caf\xE9\x81"), "café\u0081", CharsetISO88591},
+		{"shift_jis", shiftJIS, "これはテストです", CharsetShiftJIS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, charset, err := DecodeText(tt.input)
+			if err != nil {
+				t.Fatalf("DecodeText failed: %v", err)
+			}
+			if text != tt.text || charset != tt.expected {
+				t.Errorf("got %q as %s, want %q as %s", text, charset, tt.text, tt.expected)
+			}
+		})
+	}
+
+	if text, err := DecodeTextCharset([]byte("f\xFCck"), "latin1"); err != nil || text != "fück" {
+		t.Errorf("got %q (%v) for a declared charset, want %q", text, err, "fück")
+	}
+	if _, err := DecodeTextCharset([]byte("x"), "no-such-charset"); err == nil {
+		t.Errorf("got no error for an unknown charset, want one")
+	}
+}
+
+func TestCheckBytes(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	trippers, err := filter.CheckBytes([]byte("f\xFB\xE7king hell"))
+	if err != nil {
+		t.Fatalf("CheckBytes failed: %v", err)
+	}
+	if len(trippers) != 1 {
+		t.Errorf("got trippers %v, want the decoded word to trip", trippers)
+	}
+}
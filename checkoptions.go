@@ -0,0 +1,67 @@
+package swearfilter
+
+import (
+	"context"
+	"time"
+)
+
+// CheckOptions adjusts a single check without touching the filter's settings, so call sites needing different
+// strictness can share one filter
+type CheckOptions struct {
+	Normalizers         []Normalizer //Normalization stages run instead of the filter's when set (see DefaultNormalizers)
+	DisableLeetSpeak    bool         //Skips leet speak translation even if the pipeline runs it
+	DisableSpacedBypass bool         //Skips the spaced bypass check even if the filter enables it
+
+	MinSeverity Severity //Only words of at least this severity trip the message, words without one counting as SeverityModerate
+	Languages   []string //Languages whose words are matched instead of ActiveLanguages when not nil, see CheckLanguages
+	MaxMatches  int      //Stops the scan once that many words tripped the message, 0 for no limit
+}
+
+// CheckWithOptions works like Check with the given options applied to this check only, giving up with ctx's error
+// once ctx is done
+//
+// The context is checked between normalization stages and between the words and patterns found in the message, so
+// a scan of a very long input stops soon after its deadline instead of running to the end. With MaxMatches set, the
+// entries found after the limit aren't looked at, so an allow entry among them doesn't get to override the others.
+func (filter *SwearFilter) CheckWithOptions(ctx context.Context, msg string, opts CheckOptions) (trippedWords []string, err error) {
+	scan := scanOptions{
+		key:                 msg,
+		ctx:                 ctx,
+		normalizers:         opts.Normalizers,
+		disableLeetSpeak:    opts.DisableLeetSpeak,
+		disableSpacedBypass: opts.DisableSpacedBypass,
+		minSeverity:         opts.MinSeverity,
+		maxMatches:          opts.MaxMatches,
+	}
+	if opts.Languages != nil {
+		scan.languages = languageSet(opts.Languages)
+	}
+	return filter.check(msg, scan)
+}
+
+// err returns the error of the scan's context once it is done
+func (opts scanOptions) err() error {
+	if opts.ctx == nil {
+		return nil
+	}
+	return opts.ctx.Err()
+}
+
+// enabled reports whether an entry takes part in the scan, given its schedule, language and severity; allow entries
+// apply whatever their severity; the filter's lock must be held
+func (filter *SwearFilter) enabled(entry WordOptions, opts scanOptions, now time.Time) bool {
+	if entry.Schedule != nil && !entry.Schedule.Active(now) || !filter.languageActive(entry.Language, opts) {
+		return false
+	}
+	if opts.minSeverity == SeverityDefault {
+		return true
+	}
+	if action, _ := filter.action(entry); action == ActionAllow {
+		return true
+	}
+	severity := entry.Severity
+	if severity == SeverityDefault {
+		severity = SeverityModerate
+	}
+	return severity >= opts.minSeverity
+}
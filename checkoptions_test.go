@@ -0,0 +1,69 @@
+package swearfilter
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCheckWithOptions(t *testing.T) {
+	filter := NewSwearFilter(true, "fuck", "shit")
+	filter.AddWithOptions(WordOptions{Severity: SeverityMild}, "damn")
+	filter.AddWithOptions(WordOptions{Severity: SeveritySevere}, "cunt")
+	filter.AddLanguage("fr", "merde")
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     CheckOptions
+		expected []string
+	}{
+		{"no options", "damn shit", CheckOptions{}, []string{"damn", "shit"}},
+		{"min severity", "damn shit cunt", CheckOptions{MinSeverity: SeverityModerate}, []string{"cunt", "shit"}},
+		{"severe only", "damn shit cunt", CheckOptions{MinSeverity: SeveritySevere}, []string{"cunt"}},
+		{"languages", "merde shit", CheckOptions{Languages: []string{}}, []string{"shit"}},
+		{"max matches", "damn shit cunt", CheckOptions{MaxMatches: 2}, []string{"cunt", "damn"}},
+		{"leet speak disabled", "sh1t", CheckOptions{DisableLeetSpeak: true}, []string{}},
+		{"spaced bypass disabled", "f u c k", CheckOptions{DisableSpacedBypass: true}, []string{}},
+		{"normalizers", "FUCK", CheckOptions{Normalizers: []Normalizer{WhitespaceNormalizer{}}}, []string{}},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.CheckWithOptions(context.Background(), tt.input, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: CheckWithOptions failed: %v", tt.name, err)
+		}
+		sort.Strings(trippers)
+		if !reflect.DeepEqual(trippers, tt.expected) {
+			t.Errorf("%s: got trippers %v, want %v", tt.name, trippers, tt.expected)
+		}
+	}
+
+	if trippers, _ := filter.Check("sh1t f u c k"); len(trippers) != 2 {
+		t.Errorf("got trippers %v from Check, want the options not to stick", trippers)
+	}
+}
+
+func TestCheckWithOptionsAllowed(t *testing.T) {
+	filter := NewSwearFilter(false)
+	filter.AddWithOptions(WordOptions{Severity: SeveritySevere}, "cunt")
+	filter.AddWithOptions(WordOptions{Action: ActionAllow}, "scunthorpe")
+
+	trippers, err := filter.CheckWithOptions(context.Background(), "scunthorpe", CheckOptions{MinSeverity: SeveritySevere})
+	if err != nil {
+		t.Fatalf("CheckWithOptions failed: %v", err)
+	}
+	if len(trippers) != 0 {
+		t.Errorf("got trippers %v, want the allow entry to apply whatever the minimum severity", trippers)
+	}
+}
+
+func TestCheckWithOptionsCancel(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := filter.CheckWithOptions(ctx, "fuck", CheckOptions{}); err != context.Canceled {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
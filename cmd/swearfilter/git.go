@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// runGit checks the lines added by the staged changes, a diff range with -range, or a unified diff given as the file
+// argument, and lists every line that tripped as file:line
+func runGit(args []string) (tripped bool, err error) {
+	flags := flag.NewFlagSet("git", flag.ExitOnError)
+	newFilter := filterFlags(flags)
+	diffRange := flags.String("range", "", "check the lines added in a revision range (ex: origin/main...HEAD) instead of the staged changes")
+	ignoreFile := flags.String("ignore", ".swearfilterignore", "file with glob patterns of paths to skip, one per line")
+	flags.Parse(args)
+
+	filter, err := newFilter()
+	if err != nil {
+		return false, err
+	}
+	ignored, err := readIgnoreFile(*ignoreFile, flags.Lookup("ignore").DefValue == *ignoreFile)
+	if err != nil {
+		return false, err
+	}
+
+	var diff io.Reader
+	if flags.NArg() > 0 {
+		input, err := openInput(flags)
+		if err != nil {
+			return false, err
+		}
+		defer input.Close()
+		diff = input
+	} else {
+		gitArgs := []string{"-c", "core.quotePath=false", "diff", "--no-color", "--no-ext-diff", "-U0"}
+		if *diffRange != "" {
+			gitArgs = append(gitArgs, *diffRange)
+		} else {
+			gitArgs = append(gitArgs, "--cached")
+		}
+		cmd := exec.Command("git", gitArgs...)
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return false, fmt.Errorf("git diff: %v", err)
+		}
+		diff = bytes.NewReader(out)
+	}
+
+	err = addedLines(diff, func(file string, line int, text string) error {
+		if ignored(file) {
+			return nil
+		}
+		words, err := filter.Check(text)
+		if err != nil {
+			return err
+		}
+		if len(words) > 0 {
+			tripped = true
+			fmt.Printf("%s:%d: %s\n", file, line, strings.Join(words, ", "))
+		}
+		return nil
+	})
+	return tripped, err
+}
+
+// addedLines calls fn with the file, line number and text of every line a unified diff adds
+//
+// Hunks are read for as many lines as their header counts, so added lines looking like file headers (ex: "+++ x")
+// are still added lines; a +++ line is only a file header right after a --- line between hunks.
+func addedLines(diff io.Reader, fn func(file string, line int, text string) error) error {
+	scanner := bufio.NewScanner(diff)
+	scanner.Buffer(nil, 1024*1024)
+	file, line := "", 0
+	oldLeft, newLeft := 0, 0 //Lines of the current hunk still to be read
+	previous := ""
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case oldLeft > 0 || newLeft > 0:
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if file != "" {
+					if err := fn(file, line, text[1:]); err != nil {
+						return err
+					}
+				}
+				line++
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				oldLeft--
+			case strings.HasPrefix(text, " "), text == "":
+				line++
+				oldLeft--
+				newLeft--
+			}
+		case strings.HasPrefix(text, "+++ ") && strings.HasPrefix(previous, "--- "):
+			file = diffPath(strings.TrimPrefix(text, "+++ "))
+		case strings.HasPrefix(text, "@@ "):
+			//@@ -old[,count] +new[,count] @@
+			fields := strings.Fields(text)
+			if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+				return fmt.Errorf("malformed hunk header %q", text)
+			}
+			var err error
+			if _, oldLeft, err = hunkRange(fields[1]); err != nil {
+				return fmt.Errorf("malformed hunk header %q", text)
+			}
+			if line, newLeft, err = hunkRange(fields[2]); err != nil {
+				return fmt.Errorf("malformed hunk header %q", text)
+			}
+		case strings.HasPrefix(text, "diff "):
+			file = ""
+		}
+		previous = text
+	}
+	return scanner.Err()
+}
+
+// hunkRange parses the start and line count of one side of a hunk header (ex: +12,3), the count defaulting to 1
+func hunkRange(field string) (start, count int, err error) {
+	parts := strings.SplitN(field[1:], ",", 2)
+	if start, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, err
+	}
+	count = 1
+	if len(parts) == 2 {
+		if count, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, err
+		}
+	}
+	return start, count, nil
+}
+
+// diffPath returns the path of the new file from a +++ line, or "" for deleted files
+func diffPath(name string) string {
+	if i := strings.IndexByte(name, '\t'); i >= 0 {
+		name = name[:i]
+	}
+	if unquoted, err := strconv.Unquote(name); err == nil {
+		name = unquoted
+	}
+	if name == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(name, "b/")
+}
+
+// readIgnoreFile reads gitignore-style glob patterns and returns a function reporting whether a path matches any,
+// a missing file is only an error if it was asked for explicitly
+//
+// Patterns without a slash match the name of a file or any directory it is in, patterns with one match from the root
+// of the repository, and a trailing slash only matches directories.
+func readIgnoreFile(name string, optional bool) (func(file string) bool, error) {
+	var patterns []string
+	data, err := os.ReadFile(name)
+	switch {
+	case os.IsNotExist(err) && optional:
+	case err != nil:
+		return nil, err
+	}
+	for _, pattern := range strings.Split(string(data), "\n") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" && !strings.HasPrefix(pattern, "#") {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return func(file string) bool {
+		parts := strings.Split(file, "/")
+		for _, pattern := range patterns {
+			dirOnly := strings.HasSuffix(pattern, "/")
+			pattern = strings.TrimSuffix(pattern, "/")
+			last := len(parts)
+			if dirOnly {
+				last--
+			}
+			if strings.Contains(pattern, "/") {
+				pattern = strings.TrimPrefix(pattern, "/")
+				for i := 1; i <= last; i++ {
+					if ok, _ := path.Match(pattern, strings.Join(parts[:i], "/")); ok {
+						return true
+					}
+				}
+				continue
+			}
+			for _, part := range parts[:last] {
+				if ok, _ := path.Match(pattern, part); ok {
+					return true
+				}
+			}
+		}
+		return false
+	}, nil
+}
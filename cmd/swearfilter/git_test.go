@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddedLines(t *testing.T) {
+	diff := "diff --git a/a.txt b/a.txt\n" +
+		"--- a/a.txt\n" +
+		"+++ b/a.txt\n" +
+		"@@ -1,2 +1,3 @@\n" +
+		" kept\n" +
+		"-gone\n" +
+		"+++ looks like a header\n" +
+		"+--- and so does this\n" +
+		"@@ -10 +11 @@\n" +
+		"-old\n" +
+		"+new\n" +
+		"diff --git a/gone.txt b/gone.txt\n" +
+		"--- a/gone.txt\n" +
+		"+++ /dev/null\n" +
+		"@@ -1 +0,0 @@\n" +
+		"-bye\n" +
+		"diff --git a/new.txt b/new.txt\n" +
+		"--- /dev/null\n" +
+		"+++ b/new.txt\n" +
+		"@@ -0,0 +1 @@\n" +
+		"+hello\n"
+
+	var lines []string
+	err := addedLines(strings.NewReader(diff), func(file string, line int, text string) error {
+		lines = append(lines, fmt.Sprintf("%s:%d:%s", file, line, text))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("addedLines failed: %v", err)
+	}
+	expected := []string{"a.txt:2:++ looks like a header", "a.txt:3:--- and so does this", "a.txt:11:new", "new.txt:1:hello"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("got %q, want %q", lines, expected)
+	}
+
+	if err := addedLines(strings.NewReader("@@ -1 +x @@\n"), nil); err == nil {
+		t.Error("got no error for a malformed hunk header, want one")
+	}
+}
@@ -0,0 +1,117 @@
+// Command swearfilter runs the swear filter from the command line
+//
+// Usage:
+//
+//	swearfilter <command> [flags] [file]
+//
+// Commands:
+//
+//	git        check the lines added by staged changes or a revision range, for pre-commit hooks and CI
+//	subtitles  check or censor the cue text of SRT and WebVTT files
+//
+// Every command accepts -words (a file with one word per line, # for comments, or a .json, .yaml or .yml word list,
+// see SwearFilter.LoadWordList), -w (a comma-separated list of words), -rules (a file in the rules format, see
+// SwearFilter.LoadRules) and -spaced (enable the spaced bypass). Commands exit with status 1 when something tripped
+// the filter and 2 on errors.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"swearfilter"
+)
+
+var commands = map[string]func(args []string) (tripped bool, err error){
+	"git":       runGit,
+	"subtitles": runSubtitles,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		fmt.Fprintln(os.Stderr, "usage: swearfilter <command> [flags] [file]")
+		fmt.Fprintln(os.Stderr, "commands: git, subtitles")
+		os.Exit(2)
+	}
+
+	tripped, err := commands[os.Args[1]](os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "swearfilter:", err)
+		os.Exit(2)
+	}
+	if tripped {
+		os.Exit(1)
+	}
+}
+
+// filterFlags registers the flags every command shares and returns a function building the filter from them
+func filterFlags(flags *flag.FlagSet) func() (*swearfilter.SwearFilter, error) {
+	wordsFile := flags.String("words", "", "file with one word per line, or a JSON or YAML word list")
+	words := flags.String("w", "", "comma-separated list of words")
+	rulesFile := flags.String("rules", "", "file in the rules format")
+	spaced := flags.Bool("spaced", false, "enable the spaced bypass")
+
+	return func() (*swearfilter.SwearFilter, error) {
+		filter := swearfilter.NewSwearFilter(*spaced)
+		textFile := *wordsFile
+		switch strings.ToLower(filepath.Ext(textFile)) {
+		case ".json", ".yaml", ".yml":
+			//Loading a word list replaces the words, so load it before adding the others
+			if err := filter.LoadWordList(textFile); err != nil {
+				return nil, err
+			}
+			textFile = ""
+		}
+		for _, word := range strings.Split(*words, ",") {
+			if word = strings.TrimSpace(word); word != "" {
+				filter.Add(word)
+			}
+		}
+		if textFile != "" {
+			file, err := os.Open(textFile)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				if word := strings.TrimSpace(scanner.Text()); word != "" && !strings.HasPrefix(word, "#") {
+					filter.Add(word)
+				}
+			}
+			if err = scanner.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if *rulesFile != "" {
+			file, err := os.Open(*rulesFile)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+			if err = filter.LoadRules(file); err != nil {
+				return nil, err
+			}
+		}
+		if len(filter.Words()) == 0 && len(filter.Rules()) == 0 {
+			return nil, fmt.Errorf("no words given, use -words, -w or -rules")
+		}
+		return filter, nil
+	}
+}
+
+// openInput opens the single file argument, or stdin if there is none or it is "-"
+func openInput(flags *flag.FlagSet) (io.ReadCloser, error) {
+	switch {
+	case flags.NArg() > 1:
+		return nil, fmt.Errorf("expected at most one file, got %d", flags.NArg())
+	case flags.NArg() == 0 || flags.Arg(0) == "-":
+		return os.Stdin, nil
+	}
+	return os.Open(flags.Arg(0))
+}
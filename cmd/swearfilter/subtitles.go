@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"swearfilter"
+)
+
+// runSubtitles checks a subtitle file and lists the cues that tripped, or with -censor writes the censored file
+// to stdout and lists the cues on stderr
+func runSubtitles(args []string) (tripped bool, err error) {
+	flags := flag.NewFlagSet("subtitles", flag.ExitOnError)
+	newFilter := filterFlags(flags)
+	censor := flags.Bool("censor", false, "write the censored subtitles to stdout")
+	flags.Parse(args)
+
+	filter, err := newFilter()
+	if err != nil {
+		return false, err
+	}
+	input, err := openInput(flags)
+	if err != nil {
+		return false, err
+	}
+	defer input.Close()
+
+	report := os.Stdout
+	var matches []swearfilter.CueMatch
+	if *censor {
+		report = os.Stderr
+		matches, err = filter.CensorSubtitles(input, os.Stdout)
+	} else {
+		matches, err = filter.CheckSubtitles(input)
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, match := range matches {
+		fmt.Fprintf(report, "cue %d (%s): %s\n", match.Cue, match.Timing, strings.Join(match.Words, ", "))
+	}
+	return len(matches) > 0, nil
+}
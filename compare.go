@@ -0,0 +1,65 @@
+package swearfilter
+
+import (
+	"sort"
+)
+
+// Comparison describes where two filter configurations disagree on a message
+type Comparison struct {
+	Message string   //The message that was checked
+	OnlyA   []string //Words tripped by the first filter but not the second
+	OnlyB   []string //Words tripped by the second filter but not the first
+}
+
+// Agree reports whether both filters tripped exactly the same words
+func (comparison Comparison) Agree() bool {
+	return len(comparison.OnlyA) == 0 && len(comparison.OnlyB) == 0
+}
+
+// Compare checks msg against both filters and reports the words only one of them tripped
+//
+// Neither filter's stats or hooks are touched, so Compare can be run against production filters.
+func Compare(a, b *SwearFilter, msg string) (comparison Comparison, err error) {
+	resultA, err := a.scan(msg, scanOptions{key: msg})
+	if err != nil {
+		return Comparison{}, err
+	}
+	resultB, err := b.scan(msg, scanOptions{key: msg})
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	comparison.Message = msg
+	comparison.OnlyA = difference(resultA.tripped, resultB.tripped)
+	comparison.OnlyB = difference(resultB.tripped, resultA.tripped)
+	return comparison, nil
+}
+
+// CompareAll runs Compare over every message and returns only the comparisons where the filters disagree
+func CompareAll(a, b *SwearFilter, msgs []string) (disagreements []Comparison, err error) {
+	for _, msg := range msgs {
+		comparison, err := Compare(a, b, msg)
+		if err != nil {
+			return nil, err
+		}
+		if !comparison.Agree() {
+			disagreements = append(disagreements, comparison)
+		}
+	}
+	return disagreements, nil
+}
+
+// difference returns the sorted words of a that are not in b
+func difference(a, b []string) (words []string) {
+	seen := make(map[string]struct{}, len(b))
+	for _, word := range b {
+		seen[word] = struct{}{}
+	}
+	for _, word := range a {
+		if _, ok := seen[word]; !ok {
+			words = append(words, word)
+		}
+	}
+	sort.Strings(words)
+	return
+}
@@ -0,0 +1,29 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	plain := NewSwearFilter(false, "fuck", "shit")
+	spaced := NewSwearFilter(true, "fuck", "shit")
+
+	comparison, err := Compare(plain, spaced, "f u c k that")
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if comparison.Agree() {
+		t.Errorf("got agreement, want disagreement on %q", comparison.Message)
+	}
+	if len(comparison.OnlyA) != 0 || len(comparison.OnlyB) != 1 || comparison.OnlyB[0] != "fuck" {
+		t.Errorf("got only-a %v and only-b %v, want only-b %v", comparison.OnlyA, comparison.OnlyB, []string{"fuck"})
+	}
+
+	disagreements, err := CompareAll(plain, spaced, []string{"shit", "hello", "s h i t", "f u c k"})
+	if err != nil {
+		t.Fatalf("CompareAll failed: %v", err)
+	}
+	if len(disagreements) != 2 {
+		t.Errorf("got %d disagreements, want %d: %v", len(disagreements), 2, disagreements)
+	}
+}
@@ -0,0 +1,50 @@
+package swearfilter
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables maps code points that look like ASCII letters to the letter they imitate, a subset of the Unicode
+// confusables table covering the lowercase Cyrillic, Greek, Armenian and small capital lookalikes used to dodge
+// filters; fullwidth and mathematical letters are folded by NFKC before this table is consulted
+var confusables = map[rune]rune{
+	//Cyrillic
+	'а': 'a', 'в': 'b', 'ԁ': 'd', 'е': 'e', 'ё': 'e', 'һ': 'h', 'н': 'h', 'і': 'i', 'ї': 'i', 'ј': 'j', 'к': 'k',
+	'м': 'm', 'о': 'o', 'р': 'p', 'ԛ': 'q', 'г': 'r', 'ѕ': 's', 'т': 't', 'с': 'c', 'у': 'y', 'ԝ': 'w', 'х': 'x',
+	'ь': 'b', 'п': 'n', 'ѵ': 'v',
+
+	//Greek
+	'α': 'a', 'β': 'b', 'ϲ': 'c', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't',
+	'υ': 'u', 'χ': 'x', 'ω': 'w', 'γ': 'y', 'ς': 'c',
+
+	//Armenian
+	'օ': 'o', 'ս': 'u', 'հ': 'h', 'ո': 'n', 'ց': 'g', 'ք': 'p',
+
+	//Latin lookalikes and small capitals
+	'ı': 'i', 'ɑ': 'a', 'ɡ': 'g', 'ᴀ': 'a', 'ʙ': 'b', 'ᴄ': 'c', 'ᴅ': 'd', 'ᴇ': 'e', 'ꜰ': 'f', 'ɢ': 'g', 'ʜ': 'h',
+	'ɪ': 'i', 'ᴊ': 'j', 'ᴋ': 'k', 'ʟ': 'l', 'ᴍ': 'm', 'ɴ': 'n', 'ᴏ': 'o', 'ᴘ': 'p', 'ʀ': 'r', 'ꜱ': 's', 'ᴛ': 't',
+	'ᴜ': 'u', 'ᴠ': 'v', 'ᴡ': 'w', 'ʏ': 'y', 'ᴢ': 'z',
+}
+
+// foldConfusable returns the ASCII skeleton of a single character: its compatibility form (ex: ｆ -> f, 𝐟 -> f),
+// lowercased, with every lookalike from the confusables table replaced by the letter it imitates
+func foldConfusable(r rune) string {
+	folded := strings.ToLower(norm.NFKC.String(string(r)))
+	return strings.Map(func(r rune) rune {
+		if ascii, ok := confusables[r]; ok {
+			return ascii
+		}
+		return r
+	}, folded)
+}
+
+// foldConfusables returns the ASCII skeleton of s, see foldConfusable
+func foldConfusables(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(foldConfusable(r))
+	}
+	return b.String()
+}
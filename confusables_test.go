@@ -0,0 +1,41 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestConfusableFolding(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "shit", "ass")
+
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"fսсk", 1},
+		{"ѕһіт", 1},
+		{"ΑSS", 1},
+		{"ｆｕｃｋ", 1},
+		{"𝐟𝐮𝐜𝐤", 1},
+		{"ꜰᴜᴄᴋ", 1},
+		{"фыва", 0},
+		{"καλημέρα", 0},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if len(trippers) != tt.expected {
+			t.Errorf("got trippers %v for %q, want %d", trippers, tt.input, tt.expected)
+		}
+	}
+
+	if censored, _, _ := filter.Censor("ｆｕｃｋ off"); censored != "**** off" {
+		t.Errorf("got %q, want the fullwidth word censored", censored)
+	}
+
+	filter.DisableConfusableFolding = true
+	if trippers, _ := filter.Check("fսсk"); len(trippers) != 0 {
+		t.Errorf("got trippers %v with folding disabled, want none", trippers)
+	}
+}
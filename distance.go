@@ -0,0 +1,71 @@
+package swearfilter
+
+// levenshtein returns the number of single-rune insertions, deletions and substitutions needed to turn a into b
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// editCosts are what each edit costs in damerau
+type editCosts struct {
+	edit          int          //Cost of inserting, deleting or substituting a rune
+	transposition int          //Cost of swapping two adjacent runes, not allowed if 0
+	adjacent      int          //Cost of substituting a rune with one on a neighbouring key in keys
+	keys          keyPositions //Keyboard the adjacent cost applies on, none if nil
+}
+
+// damerau returns the cost of the cheapest single-rune insertions, deletions, substitutions and transpositions of two
+// adjacent runes turning a into b, without editing any rune twice (optimal string alignment)
+func damerau(a, b string, costs editCosts) int {
+	source, target := []rune(a), []rune(b)
+	edit := costs.edit
+	rows := make([][]int, len(source)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(target)+1)
+		rows[i][0] = i * edit
+	}
+	for j := range rows[0] {
+		rows[0][j] = j * edit
+	}
+
+	for i := 1; i <= len(source); i++ {
+		for j := 1; j <= len(target); j++ {
+			cost := edit
+			switch {
+			case source[i-1] == target[j-1]:
+				cost = 0
+			case costs.keys.adjacent(source[i-1], target[j-1]):
+				cost = costs.adjacent
+			}
+			rows[i][j] = minInt(rows[i-1][j]+edit, minInt(rows[i][j-1]+edit, rows[i-1][j-1]+cost))
+			if costs.transposition > 0 && i > 1 && j > 1 && source[i-1] == target[j-2] && source[i-2] == target[j-1] {
+				rows[i][j] = minInt(rows[i][j], rows[i-2][j-2]+costs.transposition)
+			}
+		}
+	}
+	return rows[len(source)][len(target)]
+}
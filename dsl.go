@@ -0,0 +1,278 @@
+package swearfilter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LoadRules reads rules in the compact rules format from r and adds them to the filter in one step, so concurrent
+// checks see either none of them or all of them; nothing is added if any line is invalid
+//
+// Each line holds one rule, blank lines and lines starting with # are ignored. A word or phrase rule is an action
+// followed by the term and its options, quoted with double quotes when it contains spaces:
+//
+//	block fuck severity=severe category=profanity tags=core,en
+//	block ass except=assign,assess,asset
+//	block "kill yourself" category=harassment weight=5
+//	shadow newslang tags=pilot
+//	block edgyword rollout=10
+//	block merde category=profanity language=fr
+//
+// The actions are block, shadow and allow (see WordOptions.Action), or word to take the action of the word's category.
+// A category rule sets the action words of a category take by default (see SwearFilter.CategoryActions):
+//
+//	category slur block
+//	allow scunthorpe
+//
+// An allowed word only lets through the words found inside it (ex: cunt in scunthorpe), the rest of the message is
+// still checked.
+//
+// The word options are severity (mild, moderate or severe), category, weight, tags, except (see
+// WordOptions.Exceptions), whole (see WordOptions.RequireWordBoundaries), rollout (see WordOptions.RolloutPercent)
+// fuzzy (see WordOptions.MaxEditDistance) and language (see WordOptions.Language).
+// A co-occurrence rule starts with near and takes both terms, the maximum number of tokens between them and an
+// optional name:
+//
+//	near kill you within=3 name=threat
+//
+// Word and phrase terms can contain * as a wildcard standing for any number of letters and digits, which adds them
+// as pattern entries (see AddPattern) reported under the term as written:
+//
+//	block badword* category=profanity
+//
+// Co-occurrence terms can't contain wildcards.
+func (filter *SwearFilter) LoadRules(r io.Reader) error {
+	type wordRule struct {
+		term string
+		opts WordOptions
+		glob *regexp.Regexp //Set for wildcard terms
+	}
+	var words []wordRule
+	var rules []CoOccurrence
+	categories := make(map[string]Action)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields, err := splitRuleLine(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("swearfilter: rules line %d: %v", line, err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch action := fields[0]; action {
+		case "block", "shadow", "allow", "word":
+			if len(fields) < 2 {
+				return fmt.Errorf("swearfilter: rules line %d: %s needs a term", line, action)
+			}
+			rule := wordRule{term: strings.ToLower(fields[1]), opts: WordOptions{Action: ruleActions[action]}}
+			if err = parseWordOptions(&rule.opts, fields[2:]); err == nil {
+				if strings.Contains(rule.term, "*") {
+					rule.glob, err = globPattern(rule.term)
+				} else {
+					err = checkTerm(rule.term)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("swearfilter: rules line %d: %v", line, err)
+			}
+			words = append(words, rule)
+		case "near":
+			if len(fields) < 3 {
+				return fmt.Errorf("swearfilter: rules line %d: near needs two terms", line)
+			}
+			rule := CoOccurrence{A: fields[1], B: fields[2]}
+			if err = parseCoOccurrence(&rule, fields[3:]); err == nil {
+				if err = checkTerm(rule.A); err == nil {
+					err = checkTerm(rule.B)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("swearfilter: rules line %d: %v", line, err)
+			}
+			rules = append(rules, rule)
+		case "category":
+			if len(fields) != 3 || ruleActions[fields[2]] == ActionDefault {
+				return fmt.Errorf("swearfilter: rules line %d: category needs a name and one of block, shadow or allow", line)
+			}
+			categories[fields[1]] = ruleActions[fields[2]]
+		default:
+			return fmt.Errorf("swearfilter: rules line %d: unknown action %q", line, action)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	if len(categories) > 0 && filter.CategoryActions == nil {
+		filter.CategoryActions = make(map[string]Action)
+	}
+	for category, action := range categories {
+		filter.CategoryActions[category] = action
+	}
+	for _, word := range words {
+		if word.glob != nil {
+			filter.addPattern(word.term, word.glob, word.opts)
+		} else {
+			filter.addWords(word.opts, []string{word.term})
+		}
+	}
+	filter.addRules(rules)
+	return nil
+}
+
+// ruleActions maps the actions of the rules format to the action words take
+var ruleActions = map[string]Action{
+	"word":   ActionDefault,
+	"block":  ActionBlock,
+	"shadow": ActionShadow,
+	"allow":  ActionAllow,
+}
+
+// splitRuleLine splits a rules line into its fields, unquoting double-quoted fields and option values
+func splitRuleLine(line string) (fields []string, err error) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	for line != "" {
+		end := strings.IndexAny(line, " \t")
+		if end < 0 {
+			end = len(line)
+		}
+		if quote := strings.IndexByte(line[:end], '"'); quote >= 0 {
+			prefix := line[:quote]
+			quoted := quotedPrefix(line[quote:])
+			unquoted, err := strconv.Unquote(quoted)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted field %s", line[quote:])
+			}
+			fields = append(fields, prefix+unquoted)
+			line = strings.TrimLeft(line[quote+len(quoted):], " \t")
+			continue
+		}
+		fields = append(fields, line[:end])
+		line = strings.TrimLeft(line[end:], " \t")
+	}
+	return fields, nil
+}
+
+// quotedPrefix returns the double-quoted string at the start of s, up to and including its closing quote
+func quotedPrefix(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return s[:i+1]
+		}
+	}
+	return s
+}
+
+func parseWordOptions(opts *WordOptions, fields []string) error {
+	for _, field := range fields {
+		key, value, err := splitRuleOption(field)
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "severity":
+			if opts.Severity, err = parseSeverity(value); err != nil {
+				return err
+			}
+		case "category":
+			opts.Category = value
+		case "weight":
+			if opts.Weight, err = strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("invalid weight %q", value)
+			}
+		case "tags":
+			opts.Tags = splitRuleList(value)
+		case "except":
+			opts.Exceptions = splitRuleList(value)
+		case "whole":
+			if opts.RequireWordBoundaries, err = strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("invalid whole %q", value)
+			}
+		case "rollout":
+			if opts.RolloutPercent, err = strconv.Atoi(value); err != nil || opts.RolloutPercent < 0 || opts.RolloutPercent > 100 {
+				return fmt.Errorf("invalid rollout percentage %q", value)
+			}
+		case "language":
+			opts.Language = value
+		case "fuzzy":
+			if opts.MaxEditDistance, err = strconv.Atoi(value); err != nil || opts.MaxEditDistance < -1 {
+				return fmt.Errorf("invalid edit distance %q", value)
+			}
+		default:
+			return fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return nil
+}
+
+func parseCoOccurrence(rule *CoOccurrence, fields []string) error {
+	for _, field := range fields {
+		key, value, err := splitRuleOption(field)
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "within":
+			if rule.Window, err = strconv.Atoi(value); err != nil || rule.Window < 0 {
+				return fmt.Errorf("invalid window %q", value)
+			}
+		case "name":
+			rule.Name = value
+		default:
+			return fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return nil
+}
+
+func splitRuleOption(field string) (key, value string, err error) {
+	i := strings.IndexByte(field, '=')
+	if i <= 0 {
+		return "", "", fmt.Errorf("expected key=value, got %q", field)
+	}
+	return field[:i], field[i+1:], nil
+}
+
+func splitRuleList(value string) (list []string) {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func parseSeverity(value string) (Severity, error) {
+	for _, severity := range []Severity{SeverityMild, SeverityModerate, SeveritySevere} {
+		if value == severity.String() {
+			return severity, nil
+		}
+	}
+	return SeverityDefault, fmt.Errorf("unknown severity %q", value)
+}
+
+func checkTerm(term string) error {
+	if term == "" {
+		return fmt.Errorf("empty term")
+	}
+	if strings.Contains(term, "*") {
+		return fmt.Errorf("wildcard term %q is not supported", term)
+	}
+	return nil
+}
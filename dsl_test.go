@@ -0,0 +1,117 @@
+package swearfilter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadRules(t *testing.T) {
+	filter := NewSwearFilter(false)
+	err := filter.LoadRules(strings.NewReader(`
+# core list
+block Fuck severity=severe category=profanity tags=core,en
+block ass except=assign,assess
+block "kill yourself" category="self harm" weight=5
+shadow newslang tags=pilot whole=true
+block edgyword rollout=10 fuzzy=1 language=en
+  near kill you within=2 name=threat
+category slur block
+word badslur category=slur
+allow scunthorpe
+block Badword* category=profanity
+`))
+	if err != nil {
+		t.Fatalf("LoadRules failed: %v", err)
+	}
+
+	if opts, ok := filter.Options("fuck"); !ok || opts.Severity != SeveritySevere || opts.Category != "profanity" || len(opts.Tags) != 2 {
+		t.Errorf("got options %+v, %t for fuck, want severe profanity with 2 tags", opts, ok)
+	}
+	if opts, _ := filter.Options("kill yourself"); opts.Category != "self harm" || opts.Weight != 5 {
+		t.Errorf("got options %+v for the phrase, want the quoted category and weight 5", opts)
+	}
+	if opts, _ := filter.Options("newslang"); opts.Action != ActionShadow || !opts.RequireWordBoundaries {
+		t.Errorf("got options %+v for newslang, want shadow and whole", opts)
+	}
+	if opts, _ := filter.Options("edgyword"); opts.RolloutPercent != 10 || opts.MaxEditDistance != 1 || opts.Language != "en" {
+		t.Errorf("got options %+v for edgyword, want rollout 10, fuzzy 1 and language en", opts)
+	}
+	if opts, _ := filter.Options("scunthorpe"); opts.Action != ActionAllow {
+		t.Errorf("got options %+v for scunthorpe, want allow", opts)
+	}
+	if opts, _ := filter.Options("badslur"); opts.Action != ActionDefault || filter.CategoryActions["slur"] != ActionBlock {
+		t.Errorf("got options %+v and category actions %v, want badslur to take the slur category's block", opts, filter.CategoryActions)
+	}
+	if patterns := filter.Patterns(); len(patterns) != 1 || patterns[0] != "badword*" {
+		t.Errorf("got patterns %v, want the wildcard term", patterns)
+	}
+	if trippers, _ := filter.Check("such badwordery"); len(trippers) != 1 || trippers[0] != "badword*" {
+		t.Errorf("got trippers %v, want the wildcard term", trippers)
+	}
+	if rules := filter.Rules(); len(rules) != 1 || rules[0] != (CoOccurrence{Name: "threat", A: "kill", B: "you", Window: 2}) {
+		t.Errorf("got rules %+v, want the threat rule", rules)
+	}
+	if trippers, _ := filter.Check("assign it to them"); len(trippers) != 0 {
+		t.Errorf("got trippers %v for an exception, want none", trippers)
+	}
+	if trippers, _ := filter.Check("i'll kill all you"); len(trippers) != 1 || trippers[0] != "threat" {
+		t.Errorf("got trippers %v, want the threat rule", trippers)
+	}
+}
+
+func TestLoadRulesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unknown action", "deny fuck"},
+		{"missing term", "block"},
+		{"unknown option", "block fuck sev=severe"},
+		{"bad severity", "block fuck severity=extreme"},
+		{"bad rollout", "block fuck rollout=150"},
+		{"bad whole", "block fuck whole=maybe"},
+		{"bad fuzzy", "block fuck fuzzy=-2"},
+		{"bad window", "near kill you within=-1"},
+		{"one term", "near kill"},
+		{"unterminated quote", `block "kill yourself`},
+		{"wildcard", "near f*ck you"},
+		{"category without action", "category slur"},
+		{"category with word action", "category slur word"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewSwearFilter(false)
+			err := filter.LoadRules(strings.NewReader("block shit\n" + tt.input))
+			if err == nil || !strings.Contains(err.Error(), "line 2") {
+				t.Errorf("got error %v, want one for line 2", err)
+			}
+			if words := filter.Words(); len(words) != 0 {
+				t.Errorf("got words %v after a failed load, want none", words)
+			}
+		})
+	}
+}
+
+func TestLoadRulesConcurrent(t *testing.T) {
+	filter := NewSwearFilter(false)
+	rules := "block omega\nblock beta*\nnear gamma delta name=pair\n"
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			trippers, _ := filter.Check("omega betamax gamma delta")
+			if len(trippers) != 0 && len(trippers) != 3 {
+				t.Errorf("got trippers %v during LoadRules, want none or all of them", trippers)
+				return
+			}
+		}
+	}()
+	if err := filter.LoadRules(strings.NewReader(rules)); err != nil {
+		t.Fatalf("LoadRules failed: %v", err)
+	}
+	<-done
+	if trippers, _ := filter.Check("omega betamax gamma delta"); len(trippers) != 3 {
+		t.Errorf("got trippers %v, want the word, the pattern and the rule", trippers)
+	}
+}
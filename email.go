@@ -0,0 +1,125 @@
+package swearfilter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+)
+
+// emailHeaders are the headers CheckEmail checks, in the order they're reported
+var emailHeaders = []string{"From", "Reply-To", "To", "Cc", "Subject"}
+
+var (
+	regexHTMLInlineTag = regexp.MustCompile(`(?is)</?(a|abbr|b|big|code|em|font|i|mark|s|small|span|strike|strong|sub|sup|u)\b[^>]*>`)
+	regexHTMLTag       = regexp.MustCompile(`(?s)(\s*<[^>]*>\s*)+`)
+)
+
+// emailWordDecoder decodes RFC 2047 encoded-words in any charset DecodeTextCharset supports
+var emailWordDecoder = &mime.WordDecoder{
+	CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		raw, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		text, err := DecodeTextCharset(raw, charset)
+		return strings.NewReader(text), err
+	},
+}
+
+// CheckEmail parses an RFC 5322 message and checks its headers, the text of its body and the names of its attachments,
+// decoding RFC 2047 encoded-words, quoted-printable and base64 parts and their charsets first; parts labeled with a
+// charset that isn't supported have theirs detected as DecodeText does
+//
+// Matches are reported by header name (ex: Subject), or by body part: body for a single-part message, body.2.1 for the
+// first part nested in the second part of a multipart one, and body.2.filename for the name of an attachment. HTML
+// parts have their tags stripped before checking, inline ones (ex: <b>) without breaking the word they're in, and
+// non-text parts are skipped.
+func (filter *SwearFilter) CheckEmail(r io.Reader) (matches []PathMatch, err error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range emailHeaders {
+		value := msg.Header.Get(name)
+		if value == "" {
+			continue
+		}
+		if decoded, err := emailWordDecoder.DecodeHeader(value); err == nil {
+			value = decoded
+		}
+		if err = filter.checkPath(name, value, &matches); err != nil {
+			return nil, err
+		}
+	}
+
+	err = filter.checkEmailPart("body", textproto.MIMEHeader(msg.Header), msg.Body, &matches)
+	return matches, err
+}
+
+// checkEmailPart checks a single body part, recursing into multipart ones
+func (filter *SwearFilter) checkEmailPart(path string, header textproto.MIMEHeader, body io.Reader, matches *[]PathMatch) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+
+	if _, dispositionParams, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		if name := dispositionParams["filename"]; name != "" {
+			if err = filter.checkPath(path+".filename", name, matches); err != nil {
+				return err
+			}
+		}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for i := 1; ; i++ {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err = filter.checkEmailPart(fmt.Sprintf("%s.%d", path, i), part.Header, part, matches); err != nil {
+				return err
+			}
+		}
+	}
+	if !strings.HasPrefix(mediaType, "text/") {
+		return nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	raw, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	text, err := DecodeTextCharset(raw, params["charset"])
+	if err != nil {
+		//Mislabeled mail is common, detect the charset rather than skip the part
+		if text, _, err = DecodeText(raw); err != nil {
+			return err
+		}
+	}
+	if mediaType == "text/html" {
+		text = regexHTMLInlineTag.ReplaceAllString(text, "")
+		text = html.UnescapeString(regexHTMLTag.ReplaceAllString(text, " "))
+	}
+	return filter.checkPath(path, text, matches)
+}
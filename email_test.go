@@ -0,0 +1,63 @@
+package swearfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckEmail(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "shit", "cunt")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []PathMatch
+	}{
+		{"plain", "From: a@example.com\r\nSubject: hello\r\n\r\nwhat the fuck\r\n", []PathMatch{{Path: "body", Words: []string{"fuck"}}}},
+		{"encoded subject", "Subject: =?UTF-8?B?ZnVjayB5b3U=?=\r\n\r\nhi\r\n", []PathMatch{{Path: "Subject", Words: []string{"fuck"}}}},
+		{"encoded sender", "From: =?ISO-8859-1?Q?sh=EDt?= <a@example.com>\r\n\r\nhi\r\n", []PathMatch{{Path: "From", Words: []string{"shit"}}}},
+		{"quoted-printable", "Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\nf=\r\nu=63k\r\n", []PathMatch{{Path: "body", Words: []string{"fuck"}}}},
+		{"multipart", strings.Join([]string{
+			"Content-Type: multipart/mixed; boundary=outer",
+			"",
+			"--outer",
+			"Content-Type: multipart/alternative; boundary=inner",
+			"",
+			"--inner",
+			"Content-Type: text/plain",
+			"",
+			"clean text",
+			"--inner",
+			"Content-Type: text/html; charset=windows-1252",
+			"Content-Transfer-Encoding: base64",
+			"",
+			"PHA+c2g8Yj5pPC9iPnQgJmFtcDsgbW9yZTwvcD4=",
+			"--inner--",
+			"--outer",
+			"Content-Type: application/octet-stream",
+			"Content-Disposition: attachment; filename=\"=?UTF-8?Q?cunt.bin?=\"",
+			"Content-Transfer-Encoding: base64",
+			"",
+			"ZnVjaw==",
+			"--outer--",
+			"",
+		}, "\r\n"), []PathMatch{{Path: "body.1.2", Words: []string{"shit"}}, {Path: "body.2.filename", Words: []string{"cunt"}}}},
+		{"html", "Content-Type: text/html\r\n\r\n<p>fu<B>ck</B></p><p>s&#104;it</p>", []PathMatch{{Path: "body", Words: []string{"fuck", "shit"}}}},
+		{"html blocks", "Content-Type: text/html\r\n\r\n<p>fu</p><p>ck</p>", nil},
+		{"unknown charset", "Content-Type: text/plain; charset=x-bogus\r\n\r\nwhat the fuck\r\n", []PathMatch{{Path: "body", Words: []string{"fuck"}}}},
+		{"unknown legacy charset", "Content-Type: text/plain; charset=bogus\r\n\r\nsh\xedt\r\n", []PathMatch{{Path: "body", Words: []string{"shit"}}}},
+		{"unknown header charset", "Subject: =?x-bogus?Q?fuck?=\r\n\r\nhi\r\n", []PathMatch{{Path: "Subject", Words: []string{"fuck"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := filter.CheckEmail(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("CheckEmail failed: %v", err)
+			}
+			if !reflect.DeepEqual(matches, tt.expected) {
+				t.Errorf("got matches %+v, want %+v", matches, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package swearfilter
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// VariantCombined is a variant that stacks several kinds of obfuscation (ex: $ һ 1 t)
+const VariantCombined VariantKind = "combined"
+
+// Evasion is an obfuscated spelling of a wordlist entry that the filter failed to catch
+type Evasion struct {
+	Word string      //The entry from the wordlist
+	Text string      //The obfuscated spelling that slipped through
+	Kind VariantKind //The kind of obfuscation used
+}
+
+// EvasionReport is the outcome of FindEvasions
+type EvasionReport struct {
+	Tried  int       //Number of variants checked
+	Missed []Evasion //Variants the filter failed to catch, grouped by word
+}
+
+// FindEvasions red-teams the current wordlist and settings: for every word it tries the variants SuggestVariants
+// produces plus perWord randomly combined obfuscations, and reports the ones the filter fails to catch
+//
+// The same seed always produces the same variants for the same wordlist, so reports can be compared across
+// configuration changes.
+func (filter *SwearFilter) FindEvasions(seed int64, perWord int) (report EvasionReport, err error) {
+	words := filter.Words()
+	sort.Strings(words)
+	random := rand.New(rand.NewSource(seed))
+	leet := leetAlternatives(filter.leetSpeak())
+
+	for _, word := range words {
+		if strings.TrimSpace(word) == "" {
+			continue
+		}
+		variants, err := filter.SuggestVariants(word, perWord)
+		if err != nil {
+			return EvasionReport{}, err
+		}
+		for i := 0; i < perWord; i++ {
+			text := combinedVariant(random, leet, strings.ToLower(word))
+			caught, err := filter.catches(strings.ToLower(word), text)
+			if err != nil {
+				return EvasionReport{}, err
+			}
+			variants = append(variants, Variant{Text: text, Kind: VariantCombined, Caught: caught})
+		}
+
+		for _, variant := range variants {
+			report.Tried++
+			if !variant.Caught {
+				report.Missed = append(report.Missed, Evasion{Word: word, Text: variant.Text, Kind: variant.Kind})
+			}
+		}
+	}
+	return report, nil
+}
+
+// combinedVariant randomly applies leet, homoglyph, repeat and separator obfuscations to each letter of word
+func combinedVariant(random *rand.Rand, leet map[rune][]string, word string) string {
+	separator := variantSeparators[random.Intn(len(variantSeparators))]
+	spaced := random.Intn(2) == 0
+
+	var variant strings.Builder
+	for i, r := range word {
+		if i > 0 && spaced {
+			variant.WriteString(separator)
+		}
+		letter := string(r)
+		switch random.Intn(4) {
+		case 0:
+			if alts := leet[r]; len(alts) > 0 {
+				letter = alts[random.Intn(len(alts))]
+			}
+		case 1:
+			if alts := scriptHomoglyphs[r]; len(alts) > 0 {
+				letter = string(alts[random.Intn(len(alts))])
+			} else if alts := accentHomoglyphs[r]; len(alts) > 0 {
+				letter = string(alts[random.Intn(len(alts))])
+			}
+		case 2:
+			letter = strings.Repeat(letter, 2+random.Intn(2))
+		}
+		variant.WriteString(letter)
+	}
+	return variant.String()
+}
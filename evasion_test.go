@@ -0,0 +1,37 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestFindEvasions(t *testing.T) {
+	filter := NewSwearFilter(true, "shit", "fuck")
+	report, err := filter.FindEvasions(1, 20)
+	if err != nil {
+		t.Fatalf("FindEvasions failed: %v", err)
+	}
+	if report.Tried != 80 {
+		t.Errorf("got %d variants tried, want %d", report.Tried, 80)
+	}
+	if len(report.Missed) == 0 {
+		t.Fatalf("got no evasions, want separated variants to slip through")
+	}
+
+	combined := 0
+	for _, evasion := range report.Missed {
+		if caught, _ := filter.catches(evasion.Word, evasion.Text); caught {
+			t.Errorf("got evasion %q for %s, but the filter catches it", evasion.Text, evasion.Word)
+		}
+		if evasion.Kind == VariantCombined {
+			combined++
+		}
+	}
+	if combined == 0 {
+		t.Errorf("got no combined evasions, want some")
+	}
+
+	again, _ := filter.FindEvasions(1, 20)
+	if len(again.Missed) != len(report.Missed) {
+		t.Errorf("got %d evasions on the second run, want the same %d", len(again.Missed), len(report.Missed))
+	}
+}
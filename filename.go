@@ -0,0 +1,24 @@
+package swearfilter
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// CheckFilename checks a file name or path, such as an uploaded file or a custom map name
+//
+// The name is folded to its compatibility form (ex: fullwidth letters) and split into segments on path separators,
+// dots, digits, underscores, dashes and whitespace. The spaced bypass is never used, so words can't be assembled
+// across segments: "my_hell0.txt" is checked as "my hell txt".
+func (filter *SwearFilter) CheckFilename(name string) (trippedWords []string, err error) {
+	segments := strings.FieldsFunc(norm.NFKC.String(name), func(r rune) bool {
+		switch r {
+		case '/', '\\', '.', '_', '-':
+			return true
+		}
+		return unicode.IsDigit(r) || unicode.IsSpace(r)
+	})
+	return filter.check(strings.Join(segments, " "), scanOptions{key: name, disableSpacedBypass: true})
+}
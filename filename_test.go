@@ -0,0 +1,34 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestCheckFilename(t *testing.T) {
+	filter := NewSwearFilter(true, "shit", "hell", "ass")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"plain", "shit.png", []string{"shit"}},
+		{"numbered", "shit2.png", []string{"shit"}},
+		{"path", `maps\custom/hell_level.map`, []string{"hell"}},
+		{"fullwidth", "ｓｈｉｔ.txt", []string{"shit"}},
+		{"across segments", "s_h_i_t.txt", []string{}},
+		{"inner segment", "cl.ass.txt", []string{"ass"}},
+		{"clean", "holiday-2024.jpg", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trippers, err := filter.CheckFilename(tt.input)
+			if err != nil {
+				t.Fatalf("CheckFilename failed: %v", err)
+			}
+			if len(trippers) != len(tt.expected) || (len(trippers) > 0 && trippers[0] != tt.expected[0]) {
+				t.Errorf("got trippers %v, want %v", trippers, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package swearfilter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// fuzzyMinLength is how many runes a word needs to be matched fuzzily, shorter words are a single edit away from too
+// many innocent ones
+const fuzzyMinLength = 4
+
+// fuzzyEditLength is how many runes a word needs for an inserted, deleted or substituted rune to count as a single
+// edit, below it they count as two and only swapped runes are a single edit (ex: so fcuk matches fuck, while fuk, duck
+// and count don't match fuck or cunt)
+const fuzzyEditLength = 6
+
+// editDistance returns how many edits away from swear a token can be to match it fuzzily, 0 if it can't: the word's
+// MaxEditDistance or else the filter's, at most one edit per 4 runes of the word
+func (filter *SwearFilter) editDistance(swear string) int {
+	distance := filter.MaxEditDistance
+	if entry := filter.entries[swear]; entry.MaxEditDistance != 0 {
+		distance = entry.MaxEditDistance
+	}
+	length := utf8.RuneCountInString(swear)
+	if distance <= 0 || length < fuzzyMinLength {
+		return 0
+	}
+	if limit := length / 4; distance > limit {
+		distance = limit
+	}
+	return distance
+}
+
+// fuzzyOccurrences returns the byte ranges of the words of the normalized message within the rule's edit distance of
+// swear, skipping words that contain it (they are exact matches), its exceptions and allowed words
+//
+// A word must start with the same rune as swear to match, as misspellings rarely touch the first letter.
+func (filter *SwearFilter) fuzzyOccurrences(message, swear string, rule matchRule) (spans [][2]int) {
+	if rule.distance <= 0 {
+		return nil
+	}
+	first, _ := utf8.DecodeRuneInString(swear)
+	length := utf8.RuneCountInString(swear)
+	costs := filter.editCosts(length)
+
+	for _, span := range wordSpans(message) {
+		if filter.fuzzyMatch(message[span[0]:span[1]], swear, first, length, costs, rule) {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// editCosts returns what edits cost when misspelling a word of the given length, in half edits so that substitutions
+// between neighbouring keys can cost half a plain one
+//
+// The keyboard discount only applies from fuzzyEditLength runes on: on shorter words a single typo too often makes
+// another word (ex: shut for shit).
+func (filter *SwearFilter) editCosts(length int) editCosts {
+	costs := editCosts{edit: 2, transposition: 2}
+	if length < fuzzyEditLength {
+		costs.edit = 4
+	} else if keys := filter.Keyboard.positions(); keys != nil {
+		costs.adjacent, costs.keys = 1, keys
+	}
+	if filter.DisableTranspositions {
+		costs.transposition = 0
+	}
+	return costs
+}
+
+// fuzzyMatch reports whether token is a misspelling of swear close enough to match it
+func (filter *SwearFilter) fuzzyMatch(token, swear string, first rune, length int, costs editCosts, rule matchRule) bool {
+	cost, ok := filter.fuzzyCost(token, swear, first, length, costs, rule)
+	return ok && cost <= 2*rule.distance
+}
+
+// fuzzyCost returns what turning token into swear costs in half edits, and false if token can't be a misspelling of it
+// within the rule's edit distance or is excluded from matching it
+func (filter *SwearFilter) fuzzyCost(token, swear string, first rune, length int, costs editCosts, rule matchRule) (int, bool) {
+	if r, _ := utf8.DecodeRuneInString(token); r != first {
+		return 0, false
+	}
+	if difference := utf8.RuneCountInString(token) - length; difference > rule.distance || -difference > rule.distance {
+		return 0, false
+	}
+	if strings.Contains(token, swear) {
+		return 0, false
+	}
+	if _, ok := filter.allowed[token]; ok {
+		return 0, false
+	}
+	for _, exception := range rule.exceptions {
+		if token == exception {
+			return 0, false
+		}
+	}
+	return damerau(token, swear, costs), true
+}
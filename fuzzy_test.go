@@ -0,0 +1,156 @@
+package swearfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDamerau(t *testing.T) {
+	keys := KeyboardQWERTY.positions()
+	tests := []struct {
+		a, b     string
+		costs    editCosts
+		expected int
+	}{
+		{"fuck", "fuck", editCosts{edit: 1, transposition: 1}, 0},
+		{"fcuk", "fuck", editCosts{edit: 1, transposition: 1}, 1},
+		{"fcuk", "fuck", editCosts{edit: 1}, 2},
+		{"shitt", "shit", editCosts{edit: 1, transposition: 1}, 1},
+		{"fuk", "fuck", editCosts{edit: 1, transposition: 1}, 1},
+		{"fuk", "fuck", editCosts{edit: 2, transposition: 1}, 2},
+		{"fcuk", "fuck", editCosts{edit: 2, transposition: 1}, 1},
+		{"fcuk", "fuck", editCosts{edit: 2}, 4},
+		{"duck", "fuck", editCosts{edit: 1, transposition: 1}, 1},
+		{"duck", "fuck", editCosts{edit: 2, transposition: 1}, 2},
+		{"ca", "abc", editCosts{edit: 1, transposition: 1}, 3},
+		{"", "abc", editCosts{edit: 1, transposition: 1}, 3},
+		{"fuxk", "fuck", editCosts{edit: 2, adjacent: 1, keys: keys}, 1},
+		{"fubk", "fuck", editCosts{edit: 2, adjacent: 1, keys: keys}, 2},
+		{"fuxk", "fuck", editCosts{edit: 2, adjacent: 1}, 2},
+	}
+	for _, tt := range tests {
+		if got := damerau(tt.a, tt.b, tt.costs); got != tt.expected {
+			t.Errorf("damerau(%q, %q, %+v) got %d, want %d", tt.a, tt.b, tt.costs, got, tt.expected)
+		}
+	}
+}
+
+func TestMaxEditDistance(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "shit", "ass", "bastard", "cunt", "dick")
+	filter.MaxEditDistance = 1
+	filter.AddWithOptions(WordOptions{MaxEditDistance: -1}, "crap")
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"fcuk off", []string{"fuck"}},
+		{"oh sh1tt", []string{"shit"}},
+		{"fuk this", nil},
+		{"shti happens", []string{"shit"}},
+		{"bsatard", []string{"bastard"}},
+		{"basterd", []string{"bastard"}},
+		{"what the duck", nil},
+		{"funk music", nil},
+		{"night shift", nil},
+		{"nice shirt", nil},
+		{"count to ten", nil},
+		{"chunt", nil},
+		{"dirck", nil},
+		{"asx", nil},
+		{"carp", nil},
+		{"fuck", []string{"fuck"}},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if len(trippers) == 0 {
+			trippers = nil
+		}
+		if !reflect.DeepEqual(trippers, tt.expected) {
+			t.Errorf("Check(%q) got %v, want %v", tt.input, trippers, tt.expected)
+		}
+	}
+
+	filter.DisableTranspositions = true
+	for _, input := range []string{"fcuk off", "shti happens", "bsatard"} {
+		if trippers, _ := filter.Check(input); len(trippers) != 0 {
+			t.Errorf("Check(%q) got %v with transpositions off, want nothing", input, trippers)
+		}
+	}
+	if trippers, _ := filter.Check("basterd"); len(trippers) != 1 {
+		t.Errorf("got %v with transpositions off, want bastard still caught by a substitution", trippers)
+	}
+	filter.DisableTranspositions = false
+
+	filter.MaxEditDistance = 0
+	if trippers, _ := filter.Check("fcuk off"); len(trippers) != 0 {
+		t.Errorf("got %v with fuzzy matching off, want nothing", trippers)
+	}
+	filter.AddWithOptions(WordOptions{MaxEditDistance: 1}, "fuck")
+	if trippers, _ := filter.Check("fcuk off"); len(trippers) != 1 {
+		t.Errorf("got %v with fuzzy matching on for the word, want fuck", trippers)
+	}
+}
+
+func TestFuzzyMatches(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.MaxEditDistance = 1
+
+	matches, err := filter.CheckDetailed("oh FCUK it")
+	if err != nil {
+		t.Fatalf("CheckDetailed failed: %v", err)
+	}
+	expected := []Match{{Word: "fuck", Kind: MatchFuzzy, Start: 3, End: 7}}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("got %+v, want %+v", matches, expected)
+	}
+
+	if matched, match, _ := filter.TestWord("fuck", "fcuk"); !matched || match.Kind != MatchFuzzy {
+		t.Errorf("got %t, %+v from TestWord, want a fuzzy match", matched, match)
+	}
+	if censored, _, _ := filter.Censor("oh fcuk it"); censored != "oh **** it" {
+		t.Errorf("got %q from Censor, want the misspelling masked", censored)
+	}
+}
+
+func TestFuzzyShortWords(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "bitch", "bastard")
+	filter.MaxEditDistance = 1
+
+	tests := []struct {
+		input          string
+		expected       []string
+		transpositions bool //Whether the input only matches with transpositions on
+	}{
+		{"fcuk", []string{"fuck"}, true},
+		{"bicth", []string{"bitch"}, true},
+		{"bsatard", []string{"bastard"}, true},
+		{"fuk", nil, false},
+		{"fuxk", nil, false},
+		{"bich", nil, false},
+		{"bitsh", nil, false},
+		{"bastrd", []string{"bastard"}, false},
+	}
+	for _, disable := range []bool{false, true} {
+		filter.DisableTranspositions = disable
+		for _, tt := range tests {
+			trippers, err := filter.Check(tt.input)
+			if err != nil {
+				t.Fatalf("Check(%q) failed: %v", tt.input, err)
+			}
+			expected := tt.expected
+			if disable && tt.transpositions {
+				expected = nil
+			}
+			if len(trippers) == 0 {
+				trippers = nil
+			}
+			if !reflect.DeepEqual(trippers, expected) {
+				t.Errorf("Check(%q) with DisableTranspositions %t got %v, want %v", tt.input, disable, trippers, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package swearfilter
+
+// IncrementalChecker checks a buffer that is built up one edit at a time, such as a message that is still being typed
+//
+// Every edit re-checks the buffer and returns the words it currently trips. Drafts are not messages, so edits never
+// touch the filter's stats or hooks; check the final message with Check once it is sent. An IncrementalChecker is
+// not safe for concurrent use.
+type IncrementalChecker struct {
+	filter  *SwearFilter
+	buffer  []rune
+	tripped []string
+}
+
+// NewIncrementalChecker returns an empty IncrementalChecker backed by filter
+func NewIncrementalChecker(filter *SwearFilter) *IncrementalChecker {
+	return &IncrementalChecker{filter: filter}
+}
+
+// Append adds text to the end of the buffer
+func (checker *IncrementalChecker) Append(text string) (trippedWords []string, err error) {
+	checker.buffer = append(checker.buffer, []rune(text)...)
+	return checker.recheck()
+}
+
+// AppendRune adds a single rune to the end of the buffer
+func (checker *IncrementalChecker) AppendRune(r rune) (trippedWords []string, err error) {
+	checker.buffer = append(checker.buffer, r)
+	return checker.recheck()
+}
+
+// Backspace removes up to n runes from the end of the buffer, none if n isn't positive
+func (checker *IncrementalChecker) Backspace(n int) (trippedWords []string, err error) {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(checker.buffer) {
+		n = len(checker.buffer)
+	}
+	checker.buffer = checker.buffer[:len(checker.buffer)-n]
+	return checker.recheck()
+}
+
+// Reset empties the buffer
+func (checker *IncrementalChecker) Reset() {
+	checker.buffer = checker.buffer[:0]
+	checker.tripped = nil
+}
+
+// Tripped returns the words the buffer tripped as of the last edit
+func (checker *IncrementalChecker) Tripped() []string {
+	return checker.tripped
+}
+
+// String returns the current contents of the buffer
+func (checker *IncrementalChecker) String() string {
+	return string(checker.buffer)
+}
+
+func (checker *IncrementalChecker) recheck() ([]string, error) {
+	msg := string(checker.buffer)
+	result, err := checker.filter.scan(msg, scanOptions{key: msg})
+	if err != nil {
+		return nil, err
+	}
+	checker.tripped = result.tripped
+	return checker.tripped, nil
+}
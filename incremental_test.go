@@ -0,0 +1,39 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestIncrementalChecker(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	checker := NewIncrementalChecker(filter)
+
+	for _, r := range "you fuc" {
+		if trippers, _ := checker.AppendRune(r); len(trippers) != 0 {
+			t.Fatalf("got trippers %v at %q, want none yet", trippers, checker.String())
+		}
+	}
+	if trippers, _ := checker.AppendRune('k'); len(trippers) != 1 {
+		t.Errorf("got trippers %v at %q, want the word to trip", trippers, checker.String())
+	}
+	if trippers, _ := checker.Backspace(1); len(trippers) != 0 {
+		t.Errorf("got trippers %v after backspace, want none", trippers)
+	}
+	if trippers, _ := checker.Append("king hell"); len(trippers) != 1 || len(checker.Tripped()) != 1 {
+		t.Errorf("got trippers %v after append, want the word to trip", trippers)
+	}
+	if trippers, _ := checker.Backspace(100); len(trippers) != 0 || checker.String() != "" {
+		t.Errorf("got trippers %v and buffer %q, want both empty", trippers, checker.String())
+	}
+
+	checker.Append("fuck")
+	for _, n := range []int{0, -1, -100} {
+		if trippers, err := checker.Backspace(n); err != nil || len(trippers) != 1 || checker.String() != "fuck" {
+			t.Errorf("got trippers %v, %v and buffer %q after Backspace(%d), want the buffer untouched", trippers, err, checker.String(), n)
+		}
+	}
+	checker.Reset()
+	if checker.String() != "" || checker.Tripped() != nil {
+		t.Errorf("got buffer %q and trippers %v after reset, want both empty", checker.String(), checker.Tripped())
+	}
+}
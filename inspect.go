@@ -0,0 +1,54 @@
+package swearfilter
+
+// Result holds everything Inspect found in a message
+type Result struct {
+	Words []string    //Words that tripped the filter, exactly as Check would return them
+	Score float64     //Toxicity score of the tripped words, as Score would return it
+	PII   []PIIMatch  //Personal information found in the message, if DetectPII is enabled
+	Links []LinkMatch //Links to denylisted domains or, if BlockShorteners is enabled, URL shorteners
+
+	Patterns []string //Those of Words that are pattern entries rather than literal words, see AddPattern
+	Matches  []Match  //Where in the message each of Words, and any word only found phonetically, was found, as CheckDetailed would return them
+
+	Signals Signals //Shouting and flooding measurements of the message, if DetectSignals is enabled
+
+	Decision Decision //The rule that decided whether the message tripped, see Precedence
+
+	Timings []StageTiming //How long each pipeline stage took, in the order the stages ran
+
+	Err error //The error the inspection failed with, only set on results delivered by CheckAsync
+}
+
+// Inspect checks msg against the wordlist and runs every enabled auxiliary detector over it
+func (filter *SwearFilter) Inspect(msg string) (result Result, err error) {
+	var mapped mappedText
+	opts := scanOptions{key: msg, timings: &result.Timings, decision: &result.Decision, mapped: &mapped}
+	result.Words, err = filter.check(msg, opts)
+	if err != nil {
+		return Result{}, err
+	}
+	filter.mutex.RLock()
+	result.Matches, err = filter.locate(msg, mapped, result.Words)
+	filter.mutex.RUnlock()
+	if err != nil {
+		return Result{}, err
+	}
+
+	result.Score = filter.score(result.Words)
+	result.Patterns = filter.patternsIn(result.Words)
+
+	start := opts.startTiming()
+	if filter.DetectPII {
+		filter.mutex.RLock()
+		result.PII = filter.findPII(msg)
+		filter.mutex.RUnlock()
+		opts.lap(StagePII, &start)
+	}
+	result.Links = filter.CheckLinks(msg)
+	opts.lap(StageLinks, &start)
+	if filter.DetectSignals {
+		result.Signals = DetectSignals(msg)
+		opts.lap(StageSignals, &start)
+	}
+	return result, nil
+}
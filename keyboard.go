@@ -0,0 +1,65 @@
+package swearfilter
+
+import "unicode"
+
+// KeyboardLayout is the rows of letter keys of a keyboard, from top to bottom, each row sitting half a key to the right
+// of the one above it as on a standard staggered keyboard
+type KeyboardLayout []string
+
+// Common keyboard layouts (see SwearFilter.Keyboard)
+var (
+	KeyboardQWERTY = KeyboardLayout{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+	KeyboardQWERTZ = KeyboardLayout{"qwertzuiop", "asdfghjkl", "yxcvbnm"}
+	KeyboardAZERTY = KeyboardLayout{"azertyuiop", "qsdfghjklm", "wxcvbn"}
+)
+
+// keyPositions maps the runes of a keyboard layout to their row and column
+type keyPositions map[rune][2]int
+
+// positions returns where each key of the layout is, nil if it has none
+func (layout KeyboardLayout) positions() keyPositions {
+	if len(layout) == 0 {
+		return nil
+	}
+	keys := make(keyPositions)
+	for row, runes := range layout {
+		column := 0
+		for _, r := range runes {
+			keys[unicode.ToLower(r)] = [2]int{row, column}
+			column++
+		}
+	}
+	return keys
+}
+
+// Adjacent reports whether a and b are on neighbouring keys of the layout: next to each other on a row, or touching
+// across two rows
+func (layout KeyboardLayout) Adjacent(a, b rune) bool {
+	return layout.positions().adjacent(unicode.ToLower(a), unicode.ToLower(b))
+}
+
+// adjacent reports whether a and b are on neighbouring keys
+func (keys keyPositions) adjacent(a, b rune) bool {
+	if keys == nil {
+		return false
+	}
+	from, ok := keys[a]
+	if !ok {
+		return false
+	}
+	to, ok := keys[b]
+	if !ok {
+		return false
+	}
+	column := to[1] - from[1]
+	switch to[0] - from[0] {
+	case 0:
+		return column == 1 || column == -1
+	case -1:
+		//The row above is shifted half a key to the left, so a key touches the one above it and the one above right
+		return column == 0 || column == 1
+	case 1:
+		return column == 0 || column == -1
+	}
+	return false
+}
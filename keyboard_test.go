@@ -0,0 +1,73 @@
+package swearfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyboardAdjacent(t *testing.T) {
+	tests := []struct {
+		layout   KeyboardLayout
+		a, b     rune
+		expected bool
+	}{
+		{KeyboardQWERTY, 'a', 's', true},
+		{KeyboardQWERTY, 'a', 'q', true},
+		{KeyboardQWERTY, 'a', 'w', true},
+		{KeyboardQWERTY, 'a', 'z', true},
+		{KeyboardQWERTY, 's', 'x', true},
+		{KeyboardQWERTY, 'S', 'd', true},
+		{KeyboardQWERTY, 'a', 'x', false},
+		{KeyboardQWERTY, 'a', 'e', false},
+		{KeyboardQWERTY, 'a', 'a', false},
+		{KeyboardQWERTY, 'a', '1', false},
+		{KeyboardQWERTZ, 't', 'z', true},
+		{KeyboardAZERTY, 'a', 'z', true},
+		{KeyboardAZERTY, 'a', 'q', true},
+		{nil, 'a', 's', false},
+	}
+	for _, tt := range tests {
+		if got := tt.layout.Adjacent(tt.a, tt.b); got != tt.expected {
+			t.Errorf("%v.Adjacent(%q, %q) got %t, want %t", tt.layout, tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	filter := NewSwearFilter(false, "bastard", "shit", "fuck")
+	filter.MaxEditDistance = 1
+
+	if trippers, _ := filter.Check("you bsdtard"); len(trippers) != 0 {
+		t.Errorf("got %v without a keyboard, want two typos to be too far", trippers)
+	}
+
+	filter.Keyboard = KeyboardQWERTY
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"you bsdtard", []string{"bastard"}},
+		{"you bastatd", []string{"bastard"}},
+		{"you bkltard", nil},
+		{"shut up", nil},
+		{"good shot", nil},
+		{"fyck", nil},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if len(trippers) == 0 {
+			trippers = nil
+		}
+		if !reflect.DeepEqual(trippers, tt.expected) {
+			t.Errorf("Check(%q) got %v, want %v", tt.input, trippers, tt.expected)
+		}
+	}
+
+	filter.Keyboard = KeyboardAZERTY
+	if trippers, _ := filter.Check("you bsdtard"); len(trippers) != 0 {
+		t.Errorf("got %v on AZERTY, want a and s not to be neighbours there", trippers)
+	}
+}
@@ -0,0 +1,72 @@
+package swearfilter
+
+import (
+	"sort"
+	"strings"
+)
+
+// AddLanguage appends the given words to the uhohwords list under a language (see WordOptions.Language), so they can
+// be turned on and off with the language's other words
+func (filter *SwearFilter) AddLanguage(language string, badWords ...string) {
+	filter.AddWithOptions(WordOptions{Language: language}, badWords...)
+}
+
+// DeleteByLanguage deletes every word of the given language from the uhohwords list and returns how many were deleted
+func (filter *SwearFilter) DeleteByLanguage(language string) (deleted int) {
+	return filter.deleteWhere(func(opts WordOptions) bool {
+		return strings.EqualFold(opts.Language, language)
+	})
+}
+
+// Languages returns the languages words were registered under, sorted and lowercased
+func (filter *SwearFilter) Languages() (languages []string) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	seen := make(map[string]bool)
+	for word := range filter.BadWords {
+		if language := strings.ToLower(filter.entries[word].Language); language != "" && !seen[language] {
+			seen[language] = true
+			languages = append(languages, language)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// CheckLanguages works like Check with only the words of the given languages, and the words without a language,
+// enabled, whatever ActiveLanguages says
+//
+// Every language is matched by the same compiled matcher, so checking a message against a different set of languages
+// per room or per user costs nothing extra.
+func (filter *SwearFilter) CheckLanguages(msg string, languages ...string) (trippedWords []string, err error) {
+	return filter.check(msg, scanOptions{key: msg, languages: languageSet(languages)})
+}
+
+// languageSet returns the given languages as a set of lowercase codes, never nil
+func languageSet(languages []string) map[string]bool {
+	set := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		set[strings.ToLower(language)] = true
+	}
+	return set
+}
+
+// languageActive reports whether words of the given language are matched in the scan, the filter's lock must be held
+func (filter *SwearFilter) languageActive(language string, opts scanOptions) bool {
+	if language == "" {
+		return true
+	}
+	if opts.languages != nil {
+		return opts.languages[strings.ToLower(language)]
+	}
+	if len(filter.ActiveLanguages) == 0 {
+		return true
+	}
+	for _, active := range filter.ActiveLanguages {
+		if strings.EqualFold(active, language) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package swearfilter
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestActiveLanguages(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.AddLanguage("fr", "merde")
+	filter.AddLanguage("ES", "mierda")
+
+	for _, tc := range []struct {
+		name   string
+		active []string
+		msg    string
+		want   []string
+	}{
+		{"all by default", nil, "merde mierda fuck", []string{"fuck", "merde", "mierda"}},
+		{"one language", []string{"fr"}, "merde mierda fuck", []string{"fuck", "merde"}},
+		{"case-insensitive", []string{"es"}, "merde mierda", []string{"mierda"}},
+		{"unknown language", []string{"de"}, "merde mierda fuck", []string{"fuck"}},
+	} {
+		filter.ActiveLanguages = tc.active
+		trippers, err := filter.Check(tc.msg)
+		if err != nil {
+			t.Fatalf("%s: Check failed: %v", tc.name, err)
+		}
+		sort.Strings(trippers)
+		if !reflect.DeepEqual(trippers, tc.want) {
+			t.Errorf("%s: got trippers %v, want %v", tc.name, trippers, tc.want)
+		}
+	}
+}
+
+func TestCheckLanguages(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.AddLanguage("fr", "merde")
+	filter.AddLanguage("es", "mierda")
+	filter.ActiveLanguages = []string{"fr"}
+
+	for _, tc := range []struct {
+		name      string
+		languages []string
+		want      []string
+	}{
+		{"overrides the filter", []string{"es"}, []string{"fuck", "mierda"}},
+		{"several", []string{"es", "FR"}, []string{"fuck", "merde", "mierda"}},
+		{"none", nil, []string{"fuck"}},
+	} {
+		trippers, err := filter.CheckLanguages("merde mierda fuck", tc.languages...)
+		if err != nil {
+			t.Fatalf("%s: CheckLanguages failed: %v", tc.name, err)
+		}
+		sort.Strings(trippers)
+		if !reflect.DeepEqual(trippers, tc.want) {
+			t.Errorf("%s: got trippers %v, want %v", tc.name, trippers, tc.want)
+		}
+	}
+
+	if err := filter.AddPatternWithOptions(WordOptions{Language: "de"}, "schei(ss|ß)e"); err != nil {
+		t.Fatalf("AddPatternWithOptions failed: %v", err)
+	}
+	if trippers, _ := filter.CheckLanguages("scheisse"); len(trippers) != 0 {
+		t.Errorf("got trippers %v for an inactive pattern, want none", trippers)
+	}
+	if trippers, _ := filter.CheckLanguages("scheisse", "de"); len(trippers) != 1 {
+		t.Errorf("got trippers %v for an active pattern, want the pattern", trippers)
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.AddLanguage("fr", "merde", "putain")
+	filter.AddLanguage("ES", "mierda")
+
+	if languages := filter.Languages(); !reflect.DeepEqual(languages, []string{"es", "fr"}) {
+		t.Errorf("got languages %v, want es and fr", languages)
+	}
+	if deleted := filter.DeleteByLanguage("FR"); deleted != 2 {
+		t.Errorf("got %d deleted, want 2", deleted)
+	}
+	if words := filter.Words(); len(words) != 2 {
+		t.Errorf("got words %v, want fuck and mierda", words)
+	}
+}
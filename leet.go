@@ -0,0 +1,222 @@
+package swearfilter
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxInterpretations is how many interpretations of a word with ambiguous leet characters are tried by default
+const DefaultMaxInterpretations = 32
+
+// multiCharLeetKeys are the built-in sequences in the order they are replaced in
+var multiCharLeetKeys = sortedKeys(multiCharLeet)
+
+// LeetSpeakNormalizer translates leet speak to letters (ex: $h!t -> shit)
+//
+// Characters that can stand for several letters are interpreted every possible way, position by position, so mixed
+// substitutions are caught (ex: |d1ot -> idiot). Every interpretation of the message is kept, separated by
+// InterpretationSeparator (ex: $h1t -> shit shlt); a word with many ambiguous characters only has its first
+// MaxInterpretations combinations tried, the characters nearest its start varying first.
+//
+// The maps extend the built-in ones, an entry overriding the built-in entry for the same characters, and an empty
+// value removing it.
+type LeetSpeakNormalizer struct {
+	Sequences          map[string]string   //Sequences of several characters and the letter they stand for (ex: "|\\|": "n")
+	Chars              map[string]string   //Single characters and the letter they stand for (ex: "¢": "c")
+	Ambiguous          map[string][]string //Single characters that can stand for several letters, each of them being tried (ex: "1": {"i", "l"})
+	MaxInterpretations int                 //Maximum number of interpretations of a word, defaults to DefaultMaxInterpretations
+}
+
+// Stage returns StageLeet
+func (LeetSpeakNormalizer) Stage() string { return StageLeet }
+
+// Normalize translates the leet speak in msg
+func (n LeetSpeakNormalizer) Normalize(msg string) (string, error) { return normalizeString(n, msg) }
+
+func (n LeetSpeakNormalizer) normalizeMapped(message mappedText) (mappedText, error) {
+	sequences, order := multiCharLeet, multiCharLeetKeys
+	if len(n.Sequences) > 0 {
+		sequences = mergeLeet(multiCharLeet, n.Sequences)
+		order = sortedKeys(sequences)
+	}
+	for _, leet := range order {
+		message = message.replaceAll(leet, sequences[leet])
+	}
+
+	chars := mergeLeet(leetChars, n.Chars)
+	var keys asciiSet
+	for key := range chars {
+		keys.add(key)
+	}
+	message = message.mapRunes(func(r rune, char string) string {
+		if !keys.mayHave(char) {
+			return char
+		}
+		if normal, ok := chars[char]; ok {
+			return normal
+		}
+		return char
+	})
+
+	max := n.MaxInterpretations
+	if max <= 0 {
+		max = DefaultMaxInterpretations
+	}
+	return expandAmbiguous(message, n.ambiguous(), max), nil
+}
+
+// ambiguous returns the built-in ambiguous characters with the normalizer's applied
+func (n LeetSpeakNormalizer) ambiguous() map[string][]string {
+	if len(n.Ambiguous) == 0 {
+		return ambiguousLeetMap
+	}
+	ambiguous := make(map[string][]string, len(ambiguousLeetMap)+len(n.Ambiguous))
+	for leet, normals := range ambiguousLeetMap {
+		ambiguous[leet] = normals
+	}
+	for leet, normals := range n.Ambiguous {
+		if len(normals) == 0 {
+			delete(ambiguous, leet)
+		} else {
+			ambiguous[leet] = normals
+		}
+	}
+	return ambiguous
+}
+
+// mergeLeet returns the built-in map with the extra entries applied, entries with an empty value removing the
+// built-in ones
+func mergeLeet(builtin, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return builtin
+	}
+	merged := make(map[string]string, len(builtin)+len(extra))
+	for leet, normal := range builtin {
+		merged[leet] = normal
+	}
+	for leet, normal := range extra {
+		if normal == "" {
+			delete(merged, leet)
+		} else {
+			merged[leet] = normal
+		}
+	}
+	return merged
+}
+
+// ambiguousRune is an ambiguous character of a message and the letters it can stand for
+type ambiguousRune struct {
+	start, end int
+	normals    []string
+}
+
+// expandAmbiguous returns every interpretation of the ambiguous characters in message joined with
+// InterpretationSeparator, or message as is if it has none
+//
+// Every combination is enumerated when there are fewer than max. Otherwise each word gets its interpretations
+// enumerated on its own, at most max of them, and interpretation i of the message holds interpretation i of every word
+// (wrapping around for words with fewer), so each copy keeps the message's words next to each other.
+func expandAmbiguous(message mappedText, ambiguous map[string][]string, max int) mappedText {
+	var keys asciiSet
+	for key := range ambiguous {
+		keys.add(key)
+	}
+	var words [][]ambiguousRune
+	var word []ambiguousRune
+	for i := 0; i < len(message.text); {
+		r, size := utf8.DecodeRuneInString(message.text[i:])
+		char := message.text[i : i+size]
+		if unicode.IsSpace(r) {
+			if len(word) > 0 {
+				words = append(words, word)
+				word = nil
+			}
+		} else if normals, ok := keys.lookup(ambiguous, char); ok {
+			word = append(word, ambiguousRune{start: i, end: i + size, normals: normals})
+		}
+		i += size
+	}
+	if len(word) > 0 {
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return message
+	}
+
+	//Enumerate the whole message at once when it's small enough, so phrases get every combination too
+	if total := interpretationCount(joinAmbiguous(words), max); total < max {
+		words = [][]ambiguousRune{joinAmbiguous(words)}
+	}
+	copies := 1
+	counts := make([]int, len(words))
+	for i, word := range words {
+		if counts[i] = interpretationCount(word, max); counts[i] > copies {
+			copies = counts[i]
+		}
+	}
+
+	interpretations := make([]mappedText, copies)
+	for c := range interpretations {
+		var b mappedBuilder
+		b.grow(len(message.text))
+		last := 0
+		for i, word := range words {
+			choice := c % counts[i]
+			for _, char := range word {
+				b.copy(message, last, char.start)
+				start, end := message.span(char.start, char.end)
+				b.write(char.normals[choice%len(char.normals)], start, end)
+				choice /= len(char.normals)
+				last = char.end
+			}
+		}
+		b.copy(message, last, len(message.text))
+		interpretations[c] = b.mapped()
+	}
+	return joinMapped(interpretations, InterpretationSeparator)
+}
+
+// interpretationCount returns how many ways the ambiguous characters can be interpreted, at most max
+func interpretationCount(chars []ambiguousRune, max int) int {
+	count := 1
+	for _, char := range chars {
+		if count *= len(char.normals); count >= max {
+			return max
+		}
+	}
+	return count
+}
+
+// joinAmbiguous returns the ambiguous characters of every word as one list
+func joinAmbiguous(words [][]ambiguousRune) (chars []ambiguousRune) {
+	for _, word := range words {
+		chars = append(chars, word...)
+	}
+	return chars
+}
+
+// asciiSet is the set of single ASCII characters among the keys of a leet map, letting lookups skip the map for the
+// ASCII characters that aren't replaced, which most of a message is
+type asciiSet [utf8.RuneSelf]bool
+
+// add adds key if it is a single ASCII character
+func (set *asciiSet) add(key string) {
+	if len(key) == 1 && key[0] < utf8.RuneSelf {
+		set[key[0]] = true
+	}
+}
+
+// mayHave reports whether char, a single character, can be a key of the map, which only non-ASCII characters and
+// the ASCII characters in the set can be
+func (set *asciiSet) mayHave(char string) bool {
+	return char[0] >= utf8.RuneSelf || set[char[0]]
+}
+
+// lookup returns the interpretations of char in ambiguous, which the set was built from
+func (set *asciiSet) lookup(ambiguous map[string][]string, char string) ([]string, bool) {
+	if !set.mayHave(char) {
+		return nil, false
+	}
+	normals, ok := ambiguous[char]
+	return normals, ok
+}
@@ -0,0 +1,104 @@
+package swearfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAmbiguousLeet(t *testing.T) {
+	filter := NewSwearFilter(false, "idiot", "liar", "kill yourself", "shit")
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"you |d1ot", []string{"idiot"}},
+		{"such a |1ar", []string{"liar"}},
+		{"k1ll yourse|f", []string{"kill yourself"}},
+		{"$h!t", []string{"shit"}},
+		{"l1l", nil},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if len(trippers) == 0 {
+			trippers = nil
+		}
+		if !reflect.DeepEqual(trippers, tt.expected) {
+			t.Errorf("Check(%q) got %v, want %v", tt.input, trippers, tt.expected)
+		}
+	}
+}
+
+func TestAmbiguousLeetSpaced(t *testing.T) {
+	filter := NewSwearFilter(true, "ass")
+
+	//The spaced bypass must not join the end of one interpretation to the start of the next (ssi a + ssl a)
+	for _, input := range []string{"ss1 a", "ssi a"} {
+		if trippers, _ := filter.Check(input); len(trippers) != 0 {
+			t.Errorf("Check(%q) got %v, want no match across interpretations", input, trippers)
+		}
+	}
+	if trippers, _ := filter.Check("a 5$1"); len(trippers) != 1 {
+		t.Errorf("got %v, want ass spaced out within an interpretation", trippers)
+	}
+}
+
+func TestExpandAmbiguous(t *testing.T) {
+	ambiguous := map[string][]string{"1": {"i", "l"}}
+
+	tests := []struct {
+		input    string
+		max      int
+		expected string
+	}{
+		{"hello", 32, "hello"},
+		{"11", 32, "ii\u2029li\u2029il\u2029ll"},
+		{"1 1", 32, "i i\u2029l i\u2029i l\u2029l l"},
+		{"11 1", 4, "ii i\u2029li l\u2029il i\u2029ll l"},
+		{"111", 2, "iii\u2029lii"},
+	}
+	for _, tt := range tests {
+		got := expandAmbiguous(newMappedText(tt.input), ambiguous, tt.max)
+		if got.text != tt.expected {
+			t.Errorf("expandAmbiguous(%q, %d) got %q, want %q", tt.input, tt.max, got.text, tt.expected)
+		}
+	}
+}
+
+func TestLeetSpeakMaps(t *testing.T) {
+	filter := NewSwearFilter(false, "scum", "nob")
+	filter.LeetSpeak = LeetSpeakNormalizer{
+		Sequences: map[string]string{`|\|`: "n"},
+		Chars:     map[string]string{"¢": "c", "$": ""},
+		Ambiguous: map[string][]string{"%": {"u", "m"}, "1": nil},
+	}
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"s¢%%", []string{"scum"}},
+		{`|\|0b`, []string{"nob"}},
+		{"$cum", nil},
+		{"n0b", []string{"nob"}},
+	}
+	for _, tt := range tests {
+		trippers, _ := filter.Check(tt.input)
+		if len(trippers) == 0 {
+			trippers = nil
+		}
+		if !reflect.DeepEqual(trippers, tt.expected) {
+			t.Errorf("Check(%q) got %v, want %v", tt.input, trippers, tt.expected)
+		}
+	}
+	if normalized, _ := filter.Normalize("1"); normalized != "1" {
+		t.Errorf("got %q, want the removed ambiguous character left alone", normalized)
+	}
+	if normalized, _ := (LeetSpeakNormalizer{MaxInterpretations: 2}).Normalize("!!!!"); len(strings.Fields(normalized)) != 2 {
+		t.Errorf("got %q, want 2 interpretations", normalized)
+	}
+}
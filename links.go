@@ -0,0 +1,143 @@
+package swearfilter
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LinkMatch is a link found in a message that points to a denylisted domain or a URL shortener
+type LinkMatch struct {
+	URL       string //The link as it appears in the message
+	Host      string //The lowercased host name of the link, with punycode labels decoded
+	Domain    string //The denylisted domain the host matched, empty for shorteners
+	Shortener bool   //Whether the host is a known URL shortener
+	Start     int    //Byte offset of the link in the message
+	End       int    //Byte offset just past the link in the message
+}
+
+// urlShorteners is the list of URL shortener hosts reported when BlockShorteners is enabled
+var urlShorteners = map[string]struct{}{
+	"bit.ly":      {},
+	"bl.ink":      {},
+	"buff.ly":     {},
+	"cutt.ly":     {},
+	"goo.gl":      {},
+	"is.gd":       {},
+	"ow.ly":       {},
+	"rb.gy":       {},
+	"rebrand.ly":  {},
+	"s.id":        {},
+	"shorturl.at": {},
+	"t.co":        {},
+	"t.ly":        {},
+	"tiny.cc":     {},
+	"tinyurl.com": {},
+	"v.gd":        {},
+}
+
+var regexLink = regexp.MustCompile(`(?i)(?:[a-z][a-z0-9+.-]*://)?(?:[\p{L}\p{N}-]+\.)+(?:xn--[a-z0-9-]+|\p{L}{2,})(?::[0-9]+)?(?:[/?#][^\s<>"]*)?`)
+
+// FindLinks returns the offsets and host names of URLs and bare domains in msg
+func FindLinks(msg string) (links []LinkMatch) {
+	for _, loc := range regexLink.FindAllStringIndex(msg, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && msg[start-1] == '@' {
+			continue //The domain of an email address
+		}
+		end = start + len(strings.TrimRight(msg[start:end], ".,;:!?)'"))
+
+		link := msg[start:end]
+		host := link
+		if i := strings.Index(host, "://"); i >= 0 {
+			host = host[i+3:]
+		}
+		if i := strings.IndexAny(host, "/?#:"); i >= 0 {
+			host = host[:i]
+		}
+		host = decodePunycodeLabels(strings.TrimSuffix(strings.ToLower(host), "."))
+		links = append(links, LinkMatch{URL: link, Host: host, Start: start, End: end})
+	}
+	return links
+}
+
+// AddDomain adds the given domains to the link denylist, which also covers all of their subdomains
+func (filter *SwearFilter) AddDomain(domains ...string) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	if filter.domains == nil {
+		filter.domains = make(map[string]struct{})
+	}
+	for _, domain := range domains {
+		filter.domains[canonicalDomain(domain)] = struct{}{}
+	}
+}
+
+// DeleteDomain removes the given domains from the link denylist
+func (filter *SwearFilter) DeleteDomain(domains ...string) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	for _, domain := range domains {
+		delete(filter.domains, canonicalDomain(domain))
+	}
+}
+
+// Domains returns the link denylist
+func (filter *SwearFilter) Domains() (domains []string) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	for domain := range filter.domains {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return
+}
+
+// CheckLinks returns the links in msg that point to a denylisted domain or, if BlockShorteners is enabled, a URL shortener
+func (filter *SwearFilter) CheckLinks(msg string) (blocked []LinkMatch) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	if len(filter.domains) == 0 && !filter.BlockShorteners {
+		return nil
+	}
+	for _, link := range FindLinks(msg) {
+		link.Domain = filter.deniedDomain(link.Host)
+		if link.Domain == "" {
+			//Catch internationalized lookalikes of a denylisted domain
+			link.Domain = filter.deniedDomain(foldHost(link.Host))
+		}
+		if _, ok := urlShorteners[link.Host]; ok {
+			link.Shortener = true
+		}
+		if link.Domain != "" || (link.Shortener && filter.BlockShorteners) {
+			blocked = append(blocked, link)
+		}
+	}
+	return blocked
+}
+
+// deniedDomain returns the denylisted domain host is, or is a subdomain of
+func (filter *SwearFilter) deniedDomain(host string) string {
+	for domain := host; domain != ""; {
+		if _, ok := filter.domains[domain]; ok {
+			return domain
+		}
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			break
+		}
+		domain = domain[i+1:]
+	}
+	return ""
+}
+
+// canonicalDomain lowercases domain, decodes its punycode labels and strips wildcard prefixes and trailing dots
+func canonicalDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	domain = strings.TrimPrefix(domain, "*.")
+	return decodePunycodeLabels(strings.Trim(domain, "."))
+}
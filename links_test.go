@@ -0,0 +1,59 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestFindLinks(t *testing.T) {
+	links := FindLinks("see https://Sub.Example.com:8080/path?q=1, www.test.org. or mail me@mail.com")
+	if len(links) != 2 {
+		t.Fatalf("got links %v, want %d", links, 2)
+	}
+	if links[0].Host != "sub.example.com" || links[0].URL != "https://Sub.Example.com:8080/path?q=1" {
+		t.Errorf("got link %+v, want host %s", links[0], "sub.example.com")
+	}
+	if links[1].Host != "www.test.org" || links[1].URL != "www.test.org" {
+		t.Errorf("got link %+v, want trailing punctuation trimmed", links[1])
+	}
+}
+
+func TestCheckLinks(t *testing.T) {
+	filter := NewSwearFilter(false)
+	filter.AddDomain("*.badsite.com", "scam.net")
+	if domains := filter.Domains(); len(domains) != 2 || domains[0] != "badsite.com" {
+		t.Errorf("got domains %v, want canonical forms", domains)
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		shorteners bool
+		expected   []string
+	}{
+		{"exact", "go to badsite.com now", false, []string{"badsite.com"}},
+		{"subdomain", "http://cdn.x.BADSITE.com/a", false, []string{"badsite.com"}},
+		{"lookalike", "notbadsite.com is fine", false, nil},
+		{"homoglyph", "try ѕсаm.net", false, []string{"scam.net"}},
+		{"shortener off", "https://bit.ly/abc", false, nil},
+		{"shortener on", "https://bit.ly/abc and scam.net", true, []string{"", "scam.net"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter.BlockShorteners = tt.shorteners
+			links := filter.CheckLinks(tt.input)
+			if len(links) != len(tt.expected) {
+				t.Fatalf("got links %v, want domains %v", links, tt.expected)
+			}
+			for i, link := range links {
+				if link.Domain != tt.expected[i] {
+					t.Errorf("got domain %q, want %q", link.Domain, tt.expected[i])
+				}
+			}
+		})
+	}
+
+	filter.DeleteDomain("scam.net")
+	if links := filter.CheckLinks("scam.net"); len(links) != 0 {
+		t.Errorf("got links %v after delete, want none", links)
+	}
+}
@@ -0,0 +1,228 @@
+package swearfilter
+
+import (
+	"sort"
+	"strings"
+)
+
+// MatchKind is the normalization path a word was found through
+type MatchKind int
+
+// Normalization paths a word can be found through
+const (
+	MatchPlain    MatchKind = iota //Found in the normalized message without leet speak translation
+	MatchLeet                      //Only found once leet speak was translated
+	MatchSpaced                    //Only found once spaces were removed by the spaced bypass
+	MatchFuzzy                     //Only found misspelled, within the word's edit distance (see MaxEditDistance)
+	MatchPattern                   //Matched by a pattern entry (see AddPattern), Word being the pattern
+	MatchPhonetic                  //Only found by how it sounds (see SwearFilter.Phonetic), the least certain kind of match
+	MatchPII                       //Personal information (see DetectPII), Word being the name of its PIIKind
+)
+
+// String returns a lowercase name for the kind
+func (kind MatchKind) String() string {
+	switch kind {
+	case MatchPlain:
+		return "plain"
+	case MatchLeet:
+		return "leet"
+	case MatchSpaced:
+		return "spaced"
+	case MatchFuzzy:
+		return "fuzzy"
+	case MatchPattern:
+		return "pattern"
+	case MatchPhonetic:
+		return "phonetic"
+	case MatchPII:
+		return "pii"
+	}
+	return "unknown"
+}
+
+// Match describes how a word was found in a message
+type Match struct {
+	Word string
+	Kind MatchKind
+
+	Start, End int //Byte range of the original message the word was found at (see NormalizeWithOffsets), unset by TestWord
+}
+
+// CheckDetailed checks msg like Check and returns every place a tripped word was found at, in order of position
+//
+// A word found several times is returned once per occurrence. Co-occurrence rules trip the message without being
+// returned, as they don't match a single range of it. Words only found by how they sound are returned as MatchPhonetic
+// even when, without PhoneticBlocking, they don't trip the message.
+func (filter *SwearFilter) CheckDetailed(msg string) (matches []Match, err error) {
+	var mapped mappedText
+	trippedWords, err := filter.check(msg, scanOptions{key: msg, mapped: &mapped})
+	if err != nil {
+		return nil, err
+	}
+
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	return filter.locate(msg, mapped, trippedWords)
+}
+
+// locate returns every place the tripped words were found at in msg and through which normalization path, along with
+// the words only found phonetically, the filter's lock must be held
+func (filter *SwearFilter) locate(msg string, mapped mappedText, trippedWords []string) (matches []Match, err error) {
+	matches = filter.findWords(msg, mapped, trippedWords, scanOptions{})
+	if phonetic := filter.phoneticMatches(msg, mapped); len(phonetic) > 0 {
+		matches = append(matches, phonetic...)
+		sortMatches(matches)
+	}
+	for i, match := range matches {
+		if match.Kind != MatchPlain {
+			continue
+		}
+		plain, err := filter.normalize(msg[match.Start:match.End], scanOptions{disableLeetSpeak: true})
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(plain, match.Word) {
+			matches[i].Kind = MatchLeet
+		}
+	}
+	return matches, nil
+}
+
+// findWords returns every occurrence of the given words in the normalized message, sorted by position, as plain
+// matches or spaced ones if they were only found by the spaced bypass; the filter's lock must be held
+func (filter *SwearFilter) findWords(msg string, message mappedText, words []string, opts scanOptions) (matches []Match) {
+	var nospace mappedText
+	spaced := filter.EnableSpacedBypass && !opts.disableSpacedBypass
+	if spaced {
+		nospace = message.replaceAll(" ", "")
+	}
+
+	seen := make(map[Match]bool)
+	addMatch := func(word string, start, end int, kind MatchKind) {
+		key := Match{Word: word, Start: start, End: end}
+		if !seen[key] {
+			seen[key] = true
+			matches = append(matches, Match{Word: word, Kind: kind, Start: start, End: end})
+		}
+	}
+	addSpan := func(text mappedText, word string, i, j int, kind MatchKind) {
+		start, end := text.span(i, j)
+		addMatch(word, start, end, kind)
+	}
+	add := func(text mappedText, word string, offsets []int, kind MatchKind) {
+		for _, i := range offsets {
+			addSpan(text, word, i, i+len(word), kind)
+		}
+	}
+	var pii []PIIMatch
+	for _, word := range words {
+		if kind, ok := piiKind(word); ok && filter.DetectPII {
+			if pii == nil {
+				pii = filter.findPII(msg)
+			}
+			for _, match := range pii {
+				if match.Kind == kind {
+					addMatch(word, match.Start, match.End, MatchPII)
+				}
+			}
+		}
+		if p, ok := filter.patterns[word]; ok {
+			for _, span := range filter.patternOccurrences(message.text, p, msg, &message) {
+				addSpan(message, word, span[0], span[1], MatchPattern)
+			}
+		}
+		if _, ok := filter.BadWords[word]; !ok || word == " " {
+			continue
+		}
+		rule := filter.matchRule(word).mapped(msg, &message)
+		add(message, word, occurrences(message.text, word, rule), MatchPlain)
+		if spaced {
+			add(nospace, word, spacedOccurrences(message.text, word, rule), MatchSpaced)
+		}
+		for _, span := range filter.fuzzyOccurrences(message.text, word, rule) {
+			addSpan(message, word, span[0], span[1], MatchFuzzy)
+		}
+		for _, span := range filter.phoneticOccurrences(message.text, word, rule) {
+			addSpan(message, word, span[0], span[1], MatchPhonetic)
+		}
+	}
+
+	sortMatches(matches)
+	return matches
+}
+
+// firstSpans returns where in msg each of the given words was first found, the filter's lock must be held
+func (filter *SwearFilter) firstSpans(msg string, message mappedText, words []string, opts scanOptions) map[string]Span {
+	spans := make(map[string]Span, len(words))
+	for _, match := range filter.findWords(msg, message, words, opts) {
+		if _, ok := spans[match.Word]; !ok {
+			spans[match.Word] = Span{Start: match.Start, End: match.End}
+		}
+	}
+	return spans
+}
+
+// sortMatches sorts matches by position, then by word
+func sortMatches(matches []Match) {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+		if matches[i].End != matches[j].End {
+			return matches[i].End < matches[j].End
+		}
+		return matches[i].Word < matches[j].Word
+	})
+}
+
+// TestWord runs msg through the full pipeline and reports whether it matches word alone, as if word were the only
+// entry in the wordlist
+//
+// The word's activation options (Shadow, RolloutPercent and Schedule) are ignored, so the answer is the same whether
+// or not the word is currently enforced. This is meant for admin commands like "!testfilter <word> <message>".
+func (filter *SwearFilter) TestWord(word, msg string) (matched bool, match Match, err error) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	match, matched, err = filter.matchWord(word, msg)
+	return matched, match, err
+}
+
+// matchWord reports whether word is found in msg and through which normalization path, the filter's lock must be held
+func (filter *SwearFilter) matchWord(word, msg string) (match Match, found bool, err error) {
+	word = strings.ToLower(word)
+	message, err := filter.normalizeMapped(msg, scanOptions{})
+	if err != nil {
+		return Match{}, false, err
+	}
+	defer message.release()
+	rule := filter.matchRule(word)
+	if !filter.matches(message.text, word, rule.mapped(msg, &message), scanOptions{}) {
+		return Match{}, false, nil
+	}
+
+	match = Match{Word: word, Kind: MatchSpaced}
+	exact := rule
+	exact.distance, exact.phonetic = 0, ""
+	if !filter.matches(message.text, word, exact.mapped(msg, &message), scanOptions{}) {
+		match.Kind = MatchPhonetic
+		fuzzy := rule
+		fuzzy.phonetic = ""
+		if filter.matches(message.text, word, fuzzy.mapped(msg, &message), scanOptions{}) {
+			match.Kind = MatchFuzzy
+		}
+	}
+	if contains(message.text, word, rule.mapped(msg, &message)) {
+		match.Kind = MatchLeet
+		plain, err := filter.normalizeMapped(msg, scanOptions{disableLeetSpeak: true})
+		if err != nil {
+			return Match{}, false, err
+		}
+		defer plain.release()
+		if contains(plain.text, word, rule.mapped(msg, &plain)) {
+			match.Kind = MatchPlain
+		}
+	}
+	return match, true, nil
+}
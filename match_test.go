@@ -0,0 +1,64 @@
+package swearfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTestWord(t *testing.T) {
+	filter := NewSwearFilter(true)
+	filter.AddWithOptions(WordOptions{Shadow: true}, "shit")
+
+	tests := []struct {
+		name     string
+		word     string
+		input    string
+		matched  bool
+		expected MatchKind
+	}{
+		{"plain", "shit", "oh shit", true, MatchPlain},
+		{"accents", "shit", "oh shît", true, MatchPlain},
+		{"leet", "shit", "oh $h1t", true, MatchLeet},
+		{"spaced", "shit", "oh s h i t", true, MatchSpaced},
+		{"not in list", "crap", "CRAP", true, MatchPlain},
+		{"clean", "shit", "oh hi", false, MatchPlain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, match, err := filter.TestWord(tt.word, tt.input)
+			if err != nil {
+				t.Fatalf("TestWord failed: %v", err)
+			}
+			if matched != tt.matched {
+				t.Fatalf("got matched %t, want %t", matched, tt.matched)
+			}
+			if matched && (match.Kind != tt.expected || match.Word != tt.word) {
+				t.Errorf("got match %+v, want word %s via %s", match, tt.word, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckDetailed(t *testing.T) {
+	filter := NewSwearFilter(true, "fuck", "shit")
+
+	tests := []struct {
+		input    string
+		expected []Match
+	}{
+		{"what the fuck", []Match{{Word: "fuck", Kind: MatchPlain, Start: 9, End: 13}}},
+		{"Fück and sh1t", []Match{{Word: "fuck", Kind: MatchPlain, Start: 0, End: 5}, {Word: "shit", Kind: MatchLeet, Start: 10, End: 14}}},
+		{"s h i t", []Match{{Word: "shit", Kind: MatchSpaced, Start: 0, End: 7}}},
+		{"fuck fuck", []Match{{Word: "fuck", Kind: MatchPlain, Start: 0, End: 4}, {Word: "fuck", Kind: MatchPlain, Start: 5, End: 9}}},
+		{"hello", nil},
+	}
+	for _, tt := range tests {
+		matches, err := filter.CheckDetailed(tt.input)
+		if err != nil {
+			t.Fatalf("CheckDetailed(%q) failed: %v", tt.input, err)
+		}
+		if !reflect.DeepEqual(matches, tt.expected) {
+			t.Errorf("got matches %+v for %q, want %+v", matches, tt.input, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package swearfilter
+
+import (
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// NearMissEvent describes a word of a checked message that fell one edit outside the fuzzy or phonetic threshold of a
+// word from the wordlist, passed to OnNearMiss so per-word distances can be tuned on what is being missed
+type NearMissEvent struct {
+	Word        string    //The word from the wordlist that was nearly matched
+	Token       string    //The word of the normalized message that nearly matched it, empty in privacy mode
+	Kind        MatchKind //MatchFuzzy for misspellings, MatchPhonetic for words that nearly sound alike
+	Distance    int       //Edits between the two, for MatchPhonetic between their phonetic codes
+	Threshold   int       //Edits the word is matched within, one less than Distance
+	Message     string    //The message as it was passed to the check, empty in privacy mode
+	MessageHash string    //Salted hash of the message in privacy mode, so repeats can be correlated without the content
+}
+
+// nearMisses returns the words of the normalized message that are one edit too far from a fuzzy or phonetic word to
+// match it, the filter's lock must be held
+func (filter *SwearFilter) nearMisses(msg string, mapped *mappedText, opts scanOptions, now time.Time) (events []NearMissEvent) {
+	message := mapped.text
+	words := make([]string, 0, len(filter.BadWords))
+	for swear := range filter.BadWords {
+		words = append(words, swear)
+	}
+	sort.Strings(words)
+
+	spans := wordSpans(message)
+	for _, swear := range words {
+		rule := filter.matchRule(swear).mapped(msg, mapped)
+		rule.phonetic = filter.phoneticCode(swear)
+		if rule.distance <= 0 && rule.phonetic == "" || !filter.enabled(filter.entries[swear], opts, now) {
+			continue
+		}
+		if action, _ := filter.action(filter.entries[swear]); action == ActionAllow {
+			continue
+		}
+		first, _ := utf8.DecodeRuneInString(swear)
+		length := utf8.RuneCountInString(swear)
+		costs := filter.editCosts(length)
+		near := rule
+		near.distance++
+
+		for _, span := range spans {
+			token := message[span[0]:span[1]]
+			if rule.distance > 0 {
+				cost, ok := filter.fuzzyCost(token, swear, first, length, costs, near)
+				if ok && cost > 2*rule.distance && cost <= 2*near.distance {
+					events = append(events, NearMissEvent{
+						Word: swear, Token: token, Kind: MatchFuzzy, Distance: near.distance, Threshold: rule.distance,
+					})
+					continue
+				}
+			}
+			if rule.phonetic != "" && filter.phoneticCandidate(message, span, swear, rule) {
+				if code := filter.Phonetic.Encode(token); code != "" && levenshtein(code, rule.phonetic) == 1 {
+					events = append(events, NearMissEvent{Word: swear, Token: token, Kind: MatchPhonetic, Distance: 1})
+				}
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,63 @@
+package swearfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOnNearMiss(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "bastard")
+	var events []NearMissEvent
+	filter.OnNearMiss = func(event NearMissEvent) {
+		events = append(events, event)
+	}
+
+	if _, err := filter.Check("fuk you bastxrx"); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("got %+v with fuzzy matching off, want no near misses", events)
+	}
+
+	filter.MaxEditDistance = 1
+	msg := "fuk you bastxrx, fcuk off duck"
+	trippers, err := filter.Check(msg)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if !reflect.DeepEqual(trippers, []string{"fuck"}) {
+		t.Errorf("got %v, want the transposition to still match", trippers)
+	}
+	expected := []NearMissEvent{
+		{Word: "bastard", Token: "bastxrx", Kind: MatchFuzzy, Distance: 2, Threshold: 1, Message: msg},
+		{Word: "fuck", Token: "fuk", Kind: MatchFuzzy, Distance: 2, Threshold: 1, Message: msg},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("got %+v, want %+v", events, expected)
+	}
+
+	events = nil
+	filter.PrivacyMode = true
+	filter.PrivacySalt = []byte("salt")
+	if _, err := filter.Check("fuk"); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Token != "" || events[0].Message != "" || events[0].MessageHash == "" {
+		t.Errorf("got %+v in privacy mode, want only the hash of the message", events)
+	}
+	filter.PrivacyMode = false
+
+	events = nil
+	filter.MaxEditDistance = 0
+	filter.Phonetic = PhoneticMetaphone
+	if _, err := filter.Check("fucks"); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if _, err := filter.Check("fokt"); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	expected = []NearMissEvent{{Word: "fuck", Token: "fokt", Kind: MatchPhonetic, Distance: 1, Message: "fokt"}}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("got %+v from phonetic matching, want %+v", events, expected)
+	}
+}
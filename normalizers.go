@@ -0,0 +1,303 @@
+package swearfilter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer is a stage of the normalization pipeline messages go through before they are matched against the
+// wordlist (ex: translating emoji to text or undoing keyboard-adjacent typos)
+//
+// The wordlist is compared against the pipeline's output, so a stage must not reintroduce uppercase letters unless
+// the words it should match are written that way. Matches are mapped back onto the original message by the parts of
+// it a stage left untouched; a custom stage rewriting everything makes its matches span the whole rewritten range
+// unless it implements OffsetNormalizer.
+type Normalizer interface {
+	Stage() string                        //Name the stage is reported under in Result.Timings
+	Normalize(msg string) (string, error) //Returns msg with the stage applied
+}
+
+// OffsetNormalizer is a Normalizer that knows where every byte of its output came from, letting matches in text it
+// rewrote map back onto the original message precisely instead of spanning everything it changed
+type OffsetNormalizer interface {
+	Normalizer
+	NormalizeWithOffsets(msg string) (normalized string, sources []Span, err error) //Returns msg with the stage applied and, for every byte of it, the range of msg it came from
+}
+
+// mappedNormalizer is implemented by the built-in stages, which keep track of where every byte of their output came
+// from instead of letting the pipeline guess it
+type mappedNormalizer interface {
+	normalizeMapped(message mappedText) (mappedText, error)
+}
+
+// LowercaseNormalizer lowercases every letter (ex: FUCK -> fuck)
+type LowercaseNormalizer struct{}
+
+// PunycodeNormalizer decodes punycode labels in domains (ex: xn--fck-hoa -> fück)
+type PunycodeNormalizer struct{}
+
+// ConfusablesNormalizer folds lookalike characters from other scripts and fullwidth forms to the ASCII letters they
+// imitate (ex: fսсk -> fuck)
+type ConfusablesNormalizer struct{}
+
+// RepeatNormalizer collapses runs of 3 or more of the same character, keeping both one and two of them as separate
+// interpretations (ex: fuuuuck -> fuck fuuck, separated by InterpretationSeparator)
+type RepeatNormalizer struct{}
+
+// InterpretationSeparator separates the interpretations of a message normalizers keep side by side, a paragraph
+// separator: neither the whitespace normalizer nor the spaced bypass removes it, so no word is matched across two
+// interpretations
+const InterpretationSeparator = "\u2029"
+
+// AccentNormalizer strips marks from letters (ex: à -> a)
+type AccentNormalizer struct{}
+
+// WhitespaceNormalizer converts tabs into spaces, strips zero-width spaces and strips runs of whitespace
+type WhitespaceNormalizer struct {
+	KeepTabs      bool //Leaves tabs as they are
+	KeepZeroWidth bool //Leaves zero-width spaces in
+	KeepRuns      bool //Leaves runs of whitespace and leading or trailing whitespace as they are
+}
+
+// accentStrippers holds transformers decomposing text, dropping its marks and composing it back, which are stateful
+// and so can't be shared between concurrent checks
+var accentStrippers = sync.Pool{New: func() interface{} {
+	return transform.Chain(norm.NFD, transform.RemoveFunc(func(r rune) bool {
+		return unicode.Is(unicode.Mn, r)
+	}), norm.NFC)
+}}
+
+// DefaultNormalizers returns the pipeline the filter's Disable options build, used when Normalizers is nil, so it
+// can be extended instead of rebuilt from scratch
+func (filter *SwearFilter) DefaultNormalizers() []Normalizer {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	return filter.defaultNormalizers()
+}
+
+// defaultNormalizers returns the pipeline built from the Disable options, the filter's lock must be held
+func (filter *SwearFilter) defaultNormalizers() []Normalizer {
+	normalizers := []Normalizer{LowercaseNormalizer{}}
+	//Decode internationalized domain labels before leet speak mangles their digits
+	if !filter.DisablePunycodeDecoding {
+		normalizers = append(normalizers, PunycodeNormalizer{})
+	}
+	if !filter.DisableConfusableFolding {
+		normalizers = append(normalizers, ConfusablesNormalizer{})
+	}
+	//Collapse elongated words before leet speak reads the runs as other letters (ex: uu -> w)
+	if !filter.DisableRepeatCollapse {
+		normalizers = append(normalizers, RepeatNormalizer{})
+	}
+	if !filter.DisableLeetSpeak {
+		normalizers = append(normalizers, filter.LeetSpeak)
+	}
+	if !filter.DisableNormalize {
+		normalizers = append(normalizers, AccentNormalizer{})
+	}
+	return append(normalizers, WhitespaceNormalizer{
+		KeepTabs:      filter.DisableSpacedTab,
+		KeepZeroWidth: filter.DisableZeroWidthStripping,
+		KeepRuns:      filter.DisableMultiWhitespaceStripping,
+	})
+}
+
+// normalizers returns the pipeline messages go through, the filter's lock must be held
+func (filter *SwearFilter) normalizers() []Normalizer {
+	if filter.Normalizers != nil {
+		return filter.Normalizers
+	}
+	return filter.defaultNormalizers()
+}
+
+// normalizeWith applies normalizer to message
+func normalizeWith(normalizer Normalizer, message mappedText) (mappedText, error) {
+	if mapped, ok := normalizer.(mappedNormalizer); ok {
+		return mapped.normalizeMapped(message)
+	}
+	if offsets, ok := normalizer.(OffsetNormalizer); ok {
+		normalized, sources, err := offsets.NormalizeWithOffsets(message.text)
+		if err != nil {
+			return mappedText{}, err
+		}
+		if len(sources) != len(normalized) {
+			return mappedText{}, fmt.Errorf("swearfilter: %s stage returned %d offsets for %d bytes", normalizer.Stage(), len(sources), len(normalized))
+		}
+		return message.compose(normalized, sources), nil
+	}
+	normalized, err := normalizer.Normalize(message.text)
+	if err != nil {
+		return mappedText{}, err
+	}
+	return message.rewrite(normalized), nil
+}
+
+// normalizeString applies a built-in normalizer to msg
+func normalizeString(normalizer mappedNormalizer, msg string) (string, error) {
+	message, err := normalizer.normalizeMapped(newMappedText(msg))
+	return message.text, err
+}
+
+// isASCII reports whether s is made of ASCII characters only, which no stage but lowercasing and leet speak changes
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Stage returns StageLowercase
+func (LowercaseNormalizer) Stage() string { return StageLowercase }
+
+// Normalize lowercases msg
+func (n LowercaseNormalizer) Normalize(msg string) (string, error) { return normalizeString(n, msg) }
+
+func (LowercaseNormalizer) normalizeMapped(message mappedText) (mappedText, error) {
+	return message.mapRunes(func(r rune, char string) string {
+		if lower := unicode.ToLower(r); lower != r {
+			return string(lower)
+		}
+		return char
+	}), nil
+}
+
+// Stage returns StagePunycode
+func (PunycodeNormalizer) Stage() string { return StagePunycode }
+
+// Normalize decodes the punycode labels in msg
+func (n PunycodeNormalizer) Normalize(msg string) (string, error) { return normalizeString(n, msg) }
+
+func (PunycodeNormalizer) normalizeMapped(message mappedText) (mappedText, error) {
+	if !strings.Contains(strings.ToLower(message.text), punycodePrefix) {
+		return message, nil
+	}
+	return message.replaceRegexp(regexPunycodeLabel, decodePunycodeLabel), nil
+}
+
+// Stage returns StageConfusables
+func (ConfusablesNormalizer) Stage() string { return StageConfusables }
+
+// Normalize folds the lookalike characters in msg
+func (n ConfusablesNormalizer) Normalize(msg string) (string, error) { return normalizeString(n, msg) }
+
+func (ConfusablesNormalizer) normalizeMapped(message mappedText) (mappedText, error) {
+	return message.mapRunes(func(r rune, char string) string {
+		//The table has no ASCII lookalikes and NFKC leaves ASCII alone, so only uppercase letters change
+		if r < utf8.RuneSelf && !unicode.IsUpper(r) {
+			return char
+		}
+		return foldConfusable(r)
+	}), nil
+}
+
+// Stage returns StageRepeat
+func (RepeatNormalizer) Stage() string { return StageRepeat }
+
+// Normalize collapses the runs of the same character in msg
+func (n RepeatNormalizer) Normalize(msg string) (string, error) { return normalizeString(n, msg) }
+
+func (RepeatNormalizer) normalizeMapped(message mappedText) (mappedText, error) {
+	return collapseRepeats(message), nil
+}
+
+// Stage returns StageNormalize
+func (AccentNormalizer) Stage() string { return StageNormalize }
+
+// Normalize strips the marks from the letters in msg
+func (n AccentNormalizer) Normalize(msg string) (string, error) { return normalizeString(n, msg) }
+
+func (AccentNormalizer) normalizeMapped(message mappedText) (normalized mappedText, err error) {
+	if isASCII(message.text) {
+		return message, nil
+	}
+	normalize := accentStrippers.Get().(transform.Transformer)
+	defer accentStrippers.Put(normalize)
+	normalized = message.mapSegments(func(s string) int {
+		//An ASCII character followed by another one or nothing is a segment on its own
+		if s[0] < utf8.RuneSelf && (len(s) == 1 || s[1] < utf8.RuneSelf) {
+			return 1
+		}
+		return norm.NFD.NextBoundaryInString(s, true)
+	}, func(segment string) string {
+		if len(segment) == 1 && segment[0] < utf8.RuneSelf {
+			return segment
+		}
+		normalized, _, serr := transform.String(normalize, segment)
+		if serr != nil {
+			err = serr
+		}
+		return normalized
+	})
+	if err != nil {
+		return mappedText{}, err
+	}
+	return normalized, nil
+}
+
+// Stage returns StageWhitespace
+func (WhitespaceNormalizer) Stage() string { return StageWhitespace }
+
+// Normalize converts and strips the whitespace in msg
+func (n WhitespaceNormalizer) Normalize(msg string) (string, error) { return normalizeString(n, msg) }
+
+func (n WhitespaceNormalizer) normalizeMapped(message mappedText) (mappedText, error) {
+	//Turn tabs into spaces
+	if !n.KeepTabs {
+		message = message.replaceAll("\t", " ")
+	}
+
+	//Get rid of zero-width spaces
+	if !n.KeepZeroWidth {
+		message = message.replaceAll("\u200b", "")
+	}
+
+	//Convert multiple re-occurring whitespaces into a single space
+	if !n.KeepRuns {
+		message = message.replaceMatches(whitespaceRuns(message.text), func(string) string { return "" })
+	}
+	return message, nil
+}
+
+// whitespaceRuns returns the byte ranges of the leading and trailing whitespace of s and of the runs of 2 or more
+// whitespace characters inside it
+func whitespaceRuns(s string) (runs [][]int) {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isWhitespace(r) {
+			i += size
+			continue
+		}
+		start, count := i, 0
+		for i < len(s) {
+			r, size = utf8.DecodeRuneInString(s[i:])
+			if !isWhitespace(r) {
+				break
+			}
+			i += size
+			count++
+		}
+		if count >= 2 || start == 0 || i == len(s) {
+			runs = append(runs, []int{start, i})
+		}
+	}
+	return runs
+}
+
+// isWhitespace reports whether r is an ASCII whitespace character (\t, \n, \f, \r or space) or a Unicode space
+// separator
+func isWhitespace(r rune) bool {
+	switch r {
+	case '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return r >= utf8.RuneSelf && unicode.Is(unicode.Zs, r)
+}
@@ -0,0 +1,160 @@
+package swearfilter
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// emojiNormalizer spells out the middle finger emoji
+type emojiNormalizer struct{}
+
+func (emojiNormalizer) Stage() string { return "emoji" }
+
+func (emojiNormalizer) Normalize(msg string) (string, error) {
+	return strings.ReplaceAll(msg, "🖕", " fuck you "), nil
+}
+
+// fingerNormalizer spells out the middle finger emoji, keeping track of where the words came from
+type fingerNormalizer struct{}
+
+func (fingerNormalizer) Stage() string { return "finger" }
+
+func (n fingerNormalizer) Normalize(msg string) (string, error) {
+	normalized, _, err := n.NormalizeWithOffsets(msg)
+	return normalized, err
+}
+
+func (fingerNormalizer) NormalizeWithOffsets(msg string) (string, []Span, error) {
+	var normalized strings.Builder
+	var sources []Span
+	for i, r := range msg {
+		text := string(r)
+		if r == '🖕' {
+			text = "fuck"
+		}
+		normalized.WriteString(text)
+		for range text {
+			sources = append(sources, Span{i, i + len(string(r))})
+		}
+	}
+	return normalized.String(), sources, nil
+}
+
+// brokenNormalizer returns the wrong number of offsets
+type brokenNormalizer struct{ fingerNormalizer }
+
+func (brokenNormalizer) NormalizeWithOffsets(msg string) (string, []Span, error) {
+	return msg, nil, nil
+}
+
+func TestOffsetNormalizer(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.Normalizers = append([]Normalizer{fingerNormalizer{}}, filter.DefaultNormalizers()...)
+
+	matches, err := filter.CheckDetailed("🖕 and 🖕")
+	if err != nil {
+		t.Fatalf("CheckDetailed failed: %v", err)
+	}
+	expected := []Match{{Word: "fuck", Start: 0, End: 4}, {Word: "fuck", Start: 9, End: 13}}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("got %+v, want each emoji matched on its own", matches)
+	}
+
+	filter.Normalizers = []Normalizer{brokenNormalizer{}}
+	if _, err := filter.Check("oh no"); err == nil {
+		t.Errorf("got no error from a stage returning the wrong offsets, want one")
+	}
+}
+
+func TestDefaultNormalizers(t *testing.T) {
+	filter := NewSwearFilter(false)
+	filter.DisableLeetSpeak = true
+	filter.DisableSpacedTab = true
+
+	expected := []Normalizer{
+		LowercaseNormalizer{},
+		PunycodeNormalizer{},
+		ConfusablesNormalizer{},
+		RepeatNormalizer{},
+		AccentNormalizer{},
+		WhitespaceNormalizer{KeepTabs: true},
+	}
+	if got := filter.DefaultNormalizers(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v, want %#v", got, expected)
+	}
+}
+
+func TestNormalizers(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "shit", "hell")
+
+	//An explicit pipeline replaces the one the Disable options build
+	filter.Normalizers = []Normalizer{LowercaseNormalizer{}}
+	if normalized, _ := filter.Normalize("SH1T"); normalized != "sh1t" {
+		t.Errorf("got %q with only lowercasing, want sh1t", normalized)
+	}
+
+	//Custom stages can be added to the default pipeline
+	filter.Normalizers = append([]Normalizer{emojiNormalizer{}}, filter.DefaultNormalizers()...)
+	if trippers, _ := filter.Check("well 🖕"); len(trippers) != 1 || trippers[0] != "fuck" {
+		t.Errorf("got trippers %v, want the emoji spelled out", trippers)
+	}
+	if censored, _, _ := filter.Censor("oh 🖕 and hell"); censored != "oh * and ****" {
+		t.Errorf("got %q from Censor, want the emoji and hell masked", censored)
+	}
+	result, err := filter.Inspect("well 🖕")
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	if len(result.Timings) == 0 || result.Timings[0].Stage != "emoji" {
+		t.Errorf("got timings %v, want the custom stage first", result.Timings)
+	}
+
+	//Resetting goes back to the default pipeline
+	filter.Reset()
+	if filter.Normalizers != nil {
+		t.Errorf("got normalizers %v after Reset, want nil", filter.Normalizers)
+	}
+}
+
+func TestBuiltinNormalizers(t *testing.T) {
+	tests := []struct {
+		normalizer Normalizer
+		input      string
+		expected   string
+	}{
+		{LowercaseNormalizer{}, "FUCK", "fuck"},
+		{PunycodeNormalizer{}, "xn--fck-hoa.com", "fück.com"},
+		{ConfusablesNormalizer{}, "fսсk", "fuck"},
+		{RepeatNormalizer{}, "fuuuuck", "fuck" + InterpretationSeparator + "fuuck"},
+		{LeetSpeakNormalizer{}, "$h1t", "shit" + InterpretationSeparator + "shlt"},
+		{AccentNormalizer{}, "fück", "fuck"},
+		{WhitespaceNormalizer{}, "\tfuck\u200b", "fuck"},
+		{WhitespaceNormalizer{KeepTabs: true, KeepRuns: true}, "\tfuck", "\tfuck"},
+	}
+	for _, tt := range tests {
+		got, err := tt.normalizer.Normalize(tt.input)
+		if err != nil {
+			t.Fatalf("%s failed: %v", tt.normalizer.Stage(), err)
+		}
+		if got != tt.expected {
+			t.Errorf("%s got %q for %q, want %q", tt.normalizer.Stage(), got, tt.input, tt.expected)
+		}
+	}
+}
+
+func TestWhitespaceRuns(t *testing.T) {
+	//The runs the whitespace stage used to strip with regular expressions
+	leadClose := regexp.MustCompile(`^[\s\p{Zs}]+|[\s\p{Zs}]+$`)
+	inside := regexp.MustCompile(`[\s\p{Zs}]{2,}`)
+	for _, input := range []string{
+		"", " ", "   ", "fuck", " fuck ", "a  b", "a b", "a \u00a0\n b  ", "\u3000a\u2003", "a\u00a0b", "\ta\v\vb",
+	} {
+		want := inside.ReplaceAllString(leadClose.ReplaceAllString(input, ""), "")
+		got := newMappedText(input).replaceMatches(whitespaceRuns(input), func(string) string { return "" }).text
+		if got != want {
+			t.Errorf("got %q for %q, want %q", got, input, want)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package swearfilter
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Span is a byte range of a message
+type Span struct {
+	Start, End int
+}
+
+// Offsets maps a normalized message back onto the message it was normalized from
+type Offsets struct {
+	mapped mappedText
+}
+
+// NormalizeWithOffsets returns msg as the matcher sees it, like Normalize, along with where in msg every byte of it came
+// from, so anything found in the normalized message can be reported against msg
+func (filter *SwearFilter) NormalizeWithOffsets(msg string) (normalized string, offsets Offsets, err error) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	mapped, err := filter.normalizeMapped(msg, scanOptions{})
+	if err != nil {
+		return "", Offsets{}, err
+	}
+	return mapped.text, Offsets{mapped: mapped}, nil
+}
+
+// Original returns the byte range of the original message the bytes from start to end of the normalized message came
+// from, an empty range where the normalized message is empty
+func (offsets Offsets) Original(start, end int) Span {
+	if start < 0 {
+		start = 0
+	}
+	if end > len(offsets.mapped.text) {
+		end = len(offsets.mapped.text)
+	}
+	start, end = offsets.mapped.span(start, end)
+	return Span{Start: start, End: end}
+}
+
+// mappedText is a string derived from a message along with, for every byte of it, the byte range of the original
+// message that byte came from
+type mappedText struct {
+	text       string
+	start, end []int
+}
+
+// offsetBuffers are the offset slices of a mappedText that is no longer used, kept for the next one to be built in
+type offsetBuffers struct {
+	start, end []int
+}
+
+// offsetPool holds the offsetBuffers of released mappedTexts, as every stage of the pipeline maps every byte of the
+// message and would otherwise allocate two slices as long as it
+var offsetPool = sync.Pool{New: func() interface{} { return new(offsetBuffers) }}
+
+// getOffsets returns empty offset slices with room for n bytes, reused from the pool when big enough
+func getOffsets(n int) (start, end []int) {
+	buffers := offsetPool.Get().(*offsetBuffers)
+	if cap(buffers.start) < n || cap(buffers.end) < n {
+		return make([]int, 0, n), make([]int, 0, n)
+	}
+	return buffers.start[:0], buffers.end[:0]
+}
+
+// newMappedText returns s mapped onto itself, every byte spanning the rune it is part of
+func newMappedText(s string) mappedText {
+	start, end := getOffsets(len(s))
+	m := mappedText{text: s, start: start[:len(s)], end: end[:len(s)]}
+	for i := 0; i < len(s); {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		for j := i; j < i+size; j++ {
+			m.start[j], m.end[j] = i, i+size
+		}
+		i += size
+	}
+	return m
+}
+
+// release hands the offsets of m back to the pool, m must not be used afterwards
+func (m mappedText) release() {
+	if cap(m.start) == 0 || cap(m.end) == 0 {
+		return
+	}
+	offsetPool.Put(&offsetBuffers{start: m.start[:0], end: m.end[:0]})
+}
+
+// shares reports whether m and other use the same offsets, as a stage that changed nothing returns its input
+func (m mappedText) shares(other mappedText) bool {
+	return cap(m.start) > 0 && cap(other.start) > 0 && &m.start[:1][0] == &other.start[:1][0]
+}
+
+// span returns the range of the original message the bytes from i to j came from
+func (m mappedText) span(i, j int) (start, end int) {
+	if i >= j {
+		if i < len(m.start) {
+			return m.start[i], m.start[i]
+		}
+		if len(m.end) > 0 {
+			return m.end[len(m.end)-1], m.end[len(m.end)-1]
+		}
+		return 0, 0
+	}
+	start, end = m.start[i], m.end[j-1]
+	for k := i; k < j; k++ {
+		if m.start[k] < start {
+			start = m.start[k]
+		}
+		if m.end[k] > end {
+			end = m.end[k]
+		}
+	}
+	return start, end
+}
+
+// mappedBuilder accumulates a new mappedText piece by piece
+type mappedBuilder struct {
+	text       strings.Builder
+	start, end []int
+}
+
+// grow makes room for n more bytes
+func (b *mappedBuilder) grow(n int) {
+	b.text.Grow(n)
+	if b.start == nil {
+		b.start, b.end = getOffsets(n)
+	}
+}
+
+// write appends s, every byte of it coming from the original range start to end
+func (b *mappedBuilder) write(s string, start, end int) {
+	b.text.WriteString(s)
+	for i := 0; i < len(s); i++ {
+		b.start = append(b.start, start)
+		b.end = append(b.end, end)
+	}
+}
+
+// copy appends the bytes from i to j of m with their original ranges
+func (b *mappedBuilder) copy(m mappedText, i, j int) {
+	b.text.WriteString(m.text[i:j])
+	b.start = append(b.start, m.start[i:j]...)
+	b.end = append(b.end, m.end[i:j]...)
+}
+
+func (b *mappedBuilder) mapped() mappedText {
+	return mappedText{text: b.text.String(), start: b.start, end: b.end}
+}
+
+// replaceMatches replaces every byte range in matches, which must be sorted and non-overlapping, with what fn returns
+// for its text, the replacement spanning the whole original range of the text it replaced
+func (m mappedText) replaceMatches(matches [][]int, fn func(match string) string) mappedText {
+	if len(matches) == 0 {
+		return m
+	}
+	var b mappedBuilder
+	b.grow(len(m.text))
+	last := 0
+	for _, match := range matches {
+		b.copy(m, last, match[0])
+		start, end := m.span(match[0], match[1])
+		b.write(fn(m.text[match[0]:match[1]]), start, end)
+		last = match[1]
+	}
+	b.copy(m, last, len(m.text))
+	return b.mapped()
+}
+
+// replaceAll replaces every non-overlapping occurrence of old with new, like strings.ReplaceAll
+func (m mappedText) replaceAll(old, new string) mappedText {
+	if old == "" {
+		return m
+	}
+	var matches [][]int
+	for i := 0; ; {
+		j := strings.Index(m.text[i:], old)
+		if j < 0 {
+			break
+		}
+		matches = append(matches, []int{i + j, i + j + len(old)})
+		i += j + len(old)
+	}
+	return m.replaceMatches(matches, func(string) string { return new })
+}
+
+// replaceRegexp replaces every match of re with what fn returns for it
+func (m mappedText) replaceRegexp(re *regexp.Regexp, fn func(match string) string) mappedText {
+	return m.replaceMatches(re.FindAllStringIndex(m.text, -1), fn)
+}
+
+// compose returns s, an output of a stage applied to the text where sources holds, for every byte of s, the range of
+// the text it came from, mapped onto the original message
+func (m mappedText) compose(s string, sources []Span) mappedText {
+	start, end := getOffsets(len(s))
+	composed := mappedText{text: s, start: start[:len(s)], end: end[:len(s)]}
+	for i, source := range sources {
+		start, end := source.Start, source.End
+		if start < 0 {
+			start = 0
+		}
+		if end > len(m.text) {
+			end = len(m.text)
+		}
+		composed.start[i], composed.end[i] = m.span(start, end)
+	}
+	return composed
+}
+
+// rewrite returns s, a rewritten version of the text, mapped by the prefix and suffix it shares with the text: those
+// keep their original ranges and everything in between spans the original range of what it replaced
+func (m mappedText) rewrite(s string) mappedText {
+	if s == m.text {
+		return m
+	}
+	prefix := 0
+	for prefix < len(s) && prefix < len(m.text) && s[prefix] == m.text[prefix] {
+		prefix++
+	}
+	for prefix > 0 && prefix < len(s) && !utf8.RuneStart(s[prefix]) {
+		prefix--
+	}
+	suffix := 0
+	for suffix < len(s)-prefix && suffix < len(m.text)-prefix && s[len(s)-1-suffix] == m.text[len(m.text)-1-suffix] {
+		suffix++
+	}
+	for suffix > 0 && !utf8.RuneStart(s[len(s)-suffix]) {
+		suffix--
+	}
+
+	var b mappedBuilder
+	b.grow(len(s))
+	b.copy(m, 0, prefix)
+	start, end := m.span(prefix, len(m.text)-suffix)
+	b.write(s[prefix:len(s)-suffix], start, end)
+	b.copy(m, len(m.text)-suffix, len(m.text))
+	return b.mapped()
+}
+
+// mapSegments replaces every segment of the text with what fn returns for it, segments being split by next, which
+// returns the length of the segment at the start of its argument; m itself is returned if fn changed nothing
+func (m mappedText) mapSegments(next func(s string) int, fn func(segment string) string) mappedText {
+	var b mappedBuilder
+	changed, last := false, 0
+	for i := 0; i < len(m.text); {
+		n := next(m.text[i:])
+		if n <= 0 {
+			n = len(m.text) - i
+		}
+		segment := m.text[i : i+n]
+		if replaced := fn(segment); replaced != segment {
+			if !changed {
+				changed = true
+				b.grow(len(m.text))
+			}
+			b.copy(m, last, i)
+			start, end := m.span(i, i+n)
+			b.write(replaced, start, end)
+			last = i + n
+		}
+		i += n
+	}
+	if !changed {
+		return m
+	}
+	b.copy(m, last, len(m.text))
+	return b.mapped()
+}
+
+// mapRunes replaces every rune of the text with what fn returns for it, char being the rune as it is written in the
+// text so fn can return it as is without allocating
+func (m mappedText) mapRunes(fn func(r rune, char string) string) mappedText {
+	return m.mapSegments(func(s string) int {
+		if s[0] < utf8.RuneSelf {
+			return 1
+		}
+		_, size := utf8.DecodeRuneInString(s)
+		return size
+	}, func(char string) string {
+		if char[0] < utf8.RuneSelf {
+			return fn(rune(char[0]), char)
+		}
+		r, _ := utf8.DecodeRuneInString(char)
+		return fn(r, char)
+	})
+}
+
+// joinMapped concatenates the texts with sep between them, sep spanning nothing at the end of the text before it
+func joinMapped(texts []mappedText, sep string) mappedText {
+	var b mappedBuilder
+	n := len(sep) * len(texts)
+	for _, m := range texts {
+		n += len(m.text)
+	}
+	b.grow(n)
+	for i, m := range texts {
+		if i > 0 {
+			_, end := texts[i-1].span(len(texts[i-1].text), len(texts[i-1].text))
+			b.write(sep, end, end)
+		}
+		b.copy(m, 0, len(m.text))
+	}
+	return b.mapped()
+}
+
+// mergeSpans sorts spans by start and merges the overlapping ones
+func mergeSpans(spans [][2]int) [][2]int {
+	if len(spans) == 0 {
+		return nil
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0] < spans[j][0]
+	})
+	merged := [][2]int{spans[0]}
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span[0] < last[1] {
+			if span[1] > last[1] {
+				last[1] = span[1]
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+// sortedKeys returns the keys of a leet map sorted, so replacements happen in the same order on every call
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+package swearfilter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeMapped(t *testing.T) {
+	filter := NewSwearFilter(false)
+
+	tests := []struct {
+		input    string
+		word     string
+		expected string
+	}{
+		{"Fück", "fuck", "Fück"},
+		{"a  sh1t  b", "shit", "sh1t"},
+		{"​ph​uck", "fuck", "ph​uck"},
+		{"vv00t", "woot", "vv00t"},
+	}
+	for _, tt := range tests {
+		mapped, err := filter.normalizeMapped(tt.input, scanOptions{})
+		if err != nil {
+			t.Fatalf("normalizeMapped(%q) failed: %v", tt.input, err)
+		}
+		if normalized, _ := filter.Normalize(tt.input); mapped.text != normalized {
+			t.Errorf("got %q for %q, want what Normalize returns: %q", mapped.text, tt.input, normalized)
+		}
+		offsets := occurrences(mapped.text, tt.word, matchRule{})
+		if len(offsets) == 0 {
+			t.Errorf("got no %q in %q", tt.word, mapped.text)
+			continue
+		}
+		start, end := mapped.span(offsets[0], offsets[0]+len(tt.word))
+		if got := tt.input[start:end]; got != tt.expected {
+			t.Errorf("got %q for %q in %q, want %q", got, tt.word, tt.input, tt.expected)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tests := []struct {
+		input, rewritten string
+		i, j             int
+		start, end       int
+	}{
+		{"oh 🖕 and", "oh  fuck you  and", 4, 8, 3, 7},
+		{"oh 🖕 and", "oh  fuck you  and", 14, 17, 8, 11},
+		{"abc", "abc", 1, 2, 1, 2},
+		{"abc", "", 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		mapped := newMappedText(tt.input).rewrite(tt.rewritten)
+		if mapped.text != tt.rewritten {
+			t.Errorf("got %q, want %q", mapped.text, tt.rewritten)
+		}
+		if start, end := mapped.span(tt.i, tt.j); start != tt.start || end != tt.end {
+			t.Errorf("got span %d-%d of %q for %d-%d of %q, want %d-%d", start, end, tt.input, tt.i, tt.j, tt.rewritten, tt.start, tt.end)
+		}
+	}
+}
+
+func TestNormalizeWithOffsets(t *testing.T) {
+	filter := NewSwearFilter(false)
+
+	input := "Oh  $#1T!"
+	normalized, offsets, err := filter.NormalizeWithOffsets(input)
+	if err != nil {
+		t.Fatalf("NormalizeWithOffsets failed: %v", err)
+	}
+	if expected, _ := filter.Normalize(input); normalized != expected {
+		t.Errorf("got %q, want what Normalize returns: %q", normalized, expected)
+	}
+	i := strings.Index(normalized, "shit")
+	if span := offsets.Original(i, i+4); input[span.Start:span.End] != "$#1T" {
+		t.Errorf("got %q for shit, want $#1T", input[span.Start:span.End])
+	}
+	if span := offsets.Original(-1, len(normalized)+5); span != (Span{0, len(input)}) {
+		t.Errorf("got %+v for an out of range span, want it clamped to the whole message", span)
+	}
+}
+
+func TestInspectMatches(t *testing.T) {
+	filter := NewSwearFilter(false, "shit")
+
+	input := "well, SH1T"
+	result, err := filter.Inspect(input)
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	if len(result.Matches) != 1 || input[result.Matches[0].Start:result.Matches[0].End] != "SH1T" || result.Matches[0].Kind != MatchLeet {
+		t.Errorf("got matches %+v, want SH1T as a leet match", result.Matches)
+	}
+}
@@ -0,0 +1,154 @@
+package swearfilter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// pattern is a pattern entry of the blocklist
+type pattern struct {
+	re   *regexp.Regexp
+	opts WordOptions
+}
+
+// AddPattern adds regular expressions in Go's syntax (ex: f[aeiou]+ck) to the blocklist, see AddPatternWithOptions
+func (filter *SwearFilter) AddPattern(patterns ...string) error {
+	return filter.AddPatternWithOptions(WordOptions{}, patterns...)
+}
+
+// AddPatternWithOptions adds regular expressions in Go's syntax to the blocklist with the given options, replacing any
+// options a pattern was previously added with; nothing is added if any of them doesn't compile
+//
+// Patterns are compiled once and matched against the normalized message, which is lowercase, alongside the literal
+// words. A message they match trips with the pattern itself among the tripped words (see Result.Patterns) and, in
+// CheckDetailed, as a MatchPattern. Exceptions and allowed words containing the matched text suppress a match, and
+// RequireWordBoundaries makes the match stand on its own; MaxEditDistance and the spaced bypass don't apply.
+func (filter *SwearFilter) AddPatternWithOptions(opts WordOptions, patterns ...string) error {
+	compiled := make([]*regexp.Regexp, len(patterns))
+	for i, source := range patterns {
+		re, err := regexp.Compile(source)
+		if err != nil {
+			return fmt.Errorf("swearfilter: invalid pattern: %v", err)
+		}
+		compiled[i] = re
+	}
+
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	for i, source := range patterns {
+		filter.addPattern(source, compiled[i], opts)
+	}
+	return nil
+}
+
+// addPattern adds the compiled pattern under the given name, the filter's lock must be held
+func (filter *SwearFilter) addPattern(name string, re *regexp.Regexp, opts WordOptions) {
+	if filter.patterns == nil {
+		filter.patterns = make(map[string]pattern)
+	}
+	if len(opts.Exceptions) > 0 {
+		exceptions := make([]string, len(opts.Exceptions))
+		for i, exception := range opts.Exceptions {
+			exceptions[i] = strings.ToLower(exception)
+		}
+		opts.Exceptions = exceptions
+	}
+	filter.patterns[name] = pattern{re: re, opts: opts}
+}
+
+// DeletePattern deletes the given patterns from the blocklist
+func (filter *SwearFilter) DeletePattern(patterns ...string) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	for _, source := range patterns {
+		delete(filter.patterns, source)
+	}
+}
+
+// Patterns returns the pattern entries of the blocklist, sorted
+func (filter *SwearFilter) Patterns() (patterns []string) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	for source := range filter.patterns {
+		patterns = append(patterns, source)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// globPattern compiles a glob, where * stands for any number of letters, marks and digits (ex: badword* matches
+// badwords and badwordery)
+func globPattern(glob string) (*regexp.Regexp, error) {
+	parts := strings.Split(glob, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.Compile(strings.Join(parts, `[\pL\pM\pN]*`))
+}
+
+// patternsIn returns the entries among the tripped words that are patterns
+func (filter *SwearFilter) patternsIn(trippedWords []string) (patterns []string) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	for _, word := range trippedWords {
+		if _, ok := filter.patterns[word]; ok {
+			patterns = append(patterns, word)
+		}
+	}
+	return patterns
+}
+
+// options returns the options of a word or pattern entry, the filter's lock must be held
+func (filter *SwearFilter) options(rule string) WordOptions {
+	if opts, ok := filter.entries[rule]; ok {
+		return opts
+	}
+	return filter.patterns[rule].opts
+}
+
+// sortedPatterns returns the names of the patterns in sorted order, the filter's lock must be held
+func (filter *SwearFilter) sortedPatterns() []string {
+	names := make([]string, 0, len(filter.patterns))
+	for name := range filter.patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// patternOccurrences returns the byte ranges of the normalized message the pattern matches at, other than inside an
+// exception or allowed word or, if the pattern requires boundaries, inside a longer word
+func (filter *SwearFilter) patternOccurrences(message string, p pattern, original string, source *mappedText) (spans [][2]int) {
+	rule := matchRule{exceptions: p.opts.Exceptions, boundaries: filter.RequireWordBoundaries || p.opts.RequireWordBoundaries}
+	rule = rule.mapped(original, source)
+	for _, match := range p.re.FindAllStringIndex(message, -1) {
+		if match[0] == match[1] {
+			continue
+		}
+		if rule.boundaries && !rule.boundary(message, match[0], match[1]) {
+			continue
+		}
+		text := message[match[0]:match[1]]
+		if excepted(message, match[0], text, rule.exceptions) || filter.allowedAt(message, match[0], text) {
+			continue
+		}
+		spans = append(spans, [2]int{match[0], match[1]})
+	}
+	return spans
+}
+
+// allowedAt reports whether the text at byte offset at in message is part of an allowed word
+func (filter *SwearFilter) allowedAt(message string, at int, text string) bool {
+	for word := range filter.allowed {
+		if len(word) >= len(text) && excepted(message, at, text, []string{word}) {
+			return true
+		}
+	}
+	return false
+}
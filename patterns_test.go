@@ -0,0 +1,92 @@
+package swearfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddPattern(t *testing.T) {
+	filter := NewSwearFilter(false, "shit")
+	if err := filter.AddPattern(`f[aeiou]+ck`); err != nil {
+		t.Fatalf("AddPattern failed: %v", err)
+	}
+	if err := filter.AddPatternWithOptions(WordOptions{RequireWordBoundaries: true, Exceptions: []string{"Dumbbell"}}, `dumb\pL*`); err != nil {
+		t.Fatalf("AddPatternWithOptions failed: %v", err)
+	}
+	filter.AddAllowed("faeck")
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"what the FAAACK", []string{"f[aeiou]+ck"}},
+		{"fooock this shit", []string{"shit", "f[aeiou]+ck"}},
+		{"a dumbass", []string{`dumb\pL*`}},
+		{"lift a dumbbell", nil},
+		{"a faeck", nil},
+		{"fck", nil},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if len(trippers) == 0 {
+			trippers = nil
+		}
+		if !reflect.DeepEqual(trippers, tt.expected) {
+			t.Errorf("Check(%q) got %v, want %v", tt.input, trippers, tt.expected)
+		}
+	}
+
+	if err := filter.AddPattern(`f(uck`); err == nil {
+		t.Errorf("AddPattern succeeded for an invalid pattern, want an error")
+	}
+	if patterns := filter.Patterns(); !reflect.DeepEqual(patterns, []string{`dumb\pL*`, "f[aeiou]+ck"}) {
+		t.Errorf("got patterns %v, want both valid ones", patterns)
+	}
+	filter.DeletePattern(`dumb\pL*`)
+	if trippers, _ := filter.Check("a dumbass"); len(trippers) != 0 {
+		t.Errorf("got %v after deleting the pattern, want nothing", trippers)
+	}
+}
+
+func TestPatternResults(t *testing.T) {
+	filter := NewSwearFilter(false, "shit")
+	filter.AddPatternWithOptions(WordOptions{Severity: SeveritySevere}, `f[aeiou]+ck`)
+
+	result, err := filter.Inspect("fuuuck this shit")
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Patterns, []string{"f[aeiou]+ck"}) {
+		t.Errorf("got patterns %v, want the pattern reported", result.Patterns)
+	}
+	if result.Score != 3 {
+		t.Errorf("got score %v, want the pattern weighed by its severity", result.Score)
+	}
+
+	matches, err := filter.CheckDetailed("oh FAACK")
+	if err != nil {
+		t.Fatalf("CheckDetailed failed: %v", err)
+	}
+	expected := []Match{{Word: "f[aeiou]+ck", Kind: MatchPattern, Start: 3, End: 8}}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("got %+v, want %+v", matches, expected)
+	}
+	if censored, _, _ := filter.Censor("oh faack"); censored != "oh *****" {
+		t.Errorf("got %q from Censor, want the pattern masked", censored)
+	}
+}
+
+func TestGlobPattern(t *testing.T) {
+	re, err := globPattern("bad*word*")
+	if err != nil {
+		t.Fatalf("globPattern failed: %v", err)
+	}
+	for input, expected := range map[string]bool{"badword": true, "badassword": true, "badwords": true, "bad word": false} {
+		if got := re.MatchString(input); got != expected {
+			t.Errorf("got %t for %q, want %t", got, input, expected)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package swearfilter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// PhoneticAlgorithm is a way of encoding words by how they sound, so that words spelled differently but pronounced
+// alike get the same code
+type PhoneticAlgorithm int
+
+// Phonetic algorithms words can be matched by (see SwearFilter.Phonetic)
+const (
+	PhoneticNone      PhoneticAlgorithm = iota //No phonetic matching
+	PhoneticMetaphone                          //Metaphone, which knows English spelling rules (ex: phuk -> FK, like fuck)
+	PhoneticSoundex                            //Soundex, coarser and keeping the first letter as is (ex: biatch -> B320, like bitch)
+)
+
+// phoneticMinLength is how many runes a word needs to be matched phonetically, shorter words sound like too many
+// innocent ones
+const phoneticMinLength = 4
+
+// String returns a lowercase name for the algorithm
+func (algorithm PhoneticAlgorithm) String() string {
+	switch algorithm {
+	case PhoneticNone:
+		return "none"
+	case PhoneticMetaphone:
+		return "metaphone"
+	case PhoneticSoundex:
+		return "soundex"
+	}
+	return "unknown"
+}
+
+// Encode returns the code of word under the algorithm, empty for PhoneticNone and for words with anything but ASCII
+// letters in them
+func (algorithm PhoneticAlgorithm) Encode(word string) string {
+	word = strings.ToLower(word)
+	if word == "" {
+		return ""
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return ""
+		}
+	}
+	switch algorithm {
+	case PhoneticMetaphone:
+		return metaphone(word)
+	case PhoneticSoundex:
+		return soundex(word)
+	}
+	return ""
+}
+
+// metaphone returns the Metaphone code of a word of lowercase ASCII letters
+func metaphone(word string) string {
+	//Initial letters that aren't pronounced or are pronounced like another
+	switch {
+	case hasAnyPrefix(word, "ae", "gn", "kn", "pn", "wr"):
+		word = word[1:]
+	case word[0] == 'x':
+		word = "s" + word[1:]
+	case strings.HasPrefix(word, "wh"):
+		word = "w" + word[2:]
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(word) {
+			return 0
+		}
+		return word[i]
+	}
+	var code strings.Builder
+	for i := 0; i < len(word); i++ {
+		c, next := word[i], at(i+1)
+		if c == at(i-1) && c != 'c' {
+			continue
+		}
+		switch c {
+		case 'a', 'e', 'i', 'o', 'u':
+			if i == 0 {
+				code.WriteByte('A')
+			}
+		case 'b':
+			if !(at(i-1) == 'm' && i == len(word)-1) {
+				code.WriteByte('B')
+			}
+		case 'c':
+			switch {
+			case next == 'i' && at(i+2) == 'a', next == 'h' && at(i-1) != 's':
+				code.WriteByte('X')
+			case next == 'h':
+				code.WriteByte('K')
+			case isFrontVowel(next):
+				if at(i-1) != 's' {
+					code.WriteByte('S')
+				}
+			default:
+				code.WriteByte('K')
+			}
+		case 'd':
+			if next == 'g' && isFrontVowel(at(i+2)) {
+				code.WriteByte('J')
+			} else {
+				code.WriteByte('T')
+			}
+		case 'g':
+			switch {
+			case next == 'h' && i+2 < len(word) && !isVowel(at(i+2)):
+			case next == 'n' && (i+2 == len(word) || word[i+2:] == "ed"):
+			case isFrontVowel(next) && at(i-1) != 'g':
+				code.WriteByte('J')
+			default:
+				code.WriteByte('K')
+			}
+		case 'h':
+			if isVowel(next) && !strings.ContainsRune("cgpst", rune(at(i-1))) {
+				code.WriteByte('H')
+			}
+		case 'k':
+			if at(i-1) != 'c' {
+				code.WriteByte('K')
+			}
+		case 'p':
+			if next == 'h' {
+				code.WriteByte('F')
+			} else {
+				code.WriteByte('P')
+			}
+		case 'q':
+			code.WriteByte('K')
+		case 's':
+			if next == 'h' || next == 'i' && (at(i+2) == 'o' || at(i+2) == 'a') {
+				code.WriteByte('X')
+			} else {
+				code.WriteByte('S')
+			}
+		case 't':
+			switch {
+			case next == 'i' && (at(i+2) == 'o' || at(i+2) == 'a'):
+				code.WriteByte('X')
+			case next == 'h':
+				code.WriteByte('0')
+			case next == 'c' && at(i+2) == 'h':
+			default:
+				code.WriteByte('T')
+			}
+		case 'v':
+			code.WriteByte('F')
+		case 'w', 'y':
+			if isVowel(next) {
+				code.WriteByte(c - 'a' + 'A')
+			}
+		case 'x':
+			code.WriteString("KS")
+		case 'z':
+			code.WriteByte('S')
+		default:
+			code.WriteByte(c - 'a' + 'A')
+		}
+	}
+	return code.String()
+}
+
+// soundexDigits are the Soundex digits of the letters from a to z, 0 for the letters that aren't coded
+const soundexDigits = "01230120022455012623010202"
+
+// soundex returns the Soundex code of a word of lowercase ASCII letters: its first letter followed by three digits
+func soundex(word string) string {
+	code := []byte{word[0] - 'a' + 'A'}
+	last := soundexDigits[word[0]-'a']
+	for i := 1; i < len(word) && len(code) < 4; i++ {
+		digit := soundexDigits[word[i]-'a']
+		switch {
+		case word[i] == 'h' || word[i] == 'w':
+			//Letters with the same digit on either side of h or w are coded once
+			continue
+		case digit == '0':
+			last = digit
+		case digit != last:
+			code = append(code, digit)
+			last = digit
+		}
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// hasAnyPrefix reports whether s starts with any of the prefixes
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isVowel(c byte) bool {
+	return c == 'a' || c == 'e' || c == 'i' || c == 'o' || c == 'u'
+}
+
+func isFrontVowel(c byte) bool {
+	return c == 'e' || c == 'i' || c == 'y'
+}
+
+// phoneticCode returns the code swear is matched phonetically by, empty if it isn't: the filter's Phonetic algorithm
+// applied to it, for words of 4 or more letters
+func (filter *SwearFilter) phoneticCode(swear string) string {
+	if filter.Phonetic == PhoneticNone || utf8.RuneCountInString(swear) < phoneticMinLength {
+		return ""
+	}
+	return filter.Phonetic.Encode(swear)
+}
+
+// phoneticOccurrences returns the byte ranges of the words of the normalized message, of 4 or more letters and
+// starting with the same sound, that sound like swear, skipping words that contain it (they are exact matches), its
+// exceptions and allowed words
+func (filter *SwearFilter) phoneticOccurrences(message, swear string, rule matchRule) (spans [][2]int) {
+	if rule.phonetic == "" {
+		return nil
+	}
+	for _, span := range wordSpans(message) {
+		if filter.phoneticMatch(message, span, swear, rule) {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// phoneticMatch reports whether the word of the normalized message at span sounds like swear, whose code is the rule's
+func (filter *SwearFilter) phoneticMatch(message string, span [2]int, swear string, rule matchRule) bool {
+	return filter.phoneticCandidate(message, span, swear, rule) &&
+		filter.Phonetic.Encode(message[span[0]:span[1]]) == rule.phonetic
+}
+
+// phoneticCandidate reports whether the word of the normalized message at span may be matched phonetically against
+// swear, whatever its code
+//
+// The word must be 4 or more letters long as written in the original message when known, as normalization shortens
+// some spellings (ex: phuk -> fuk).
+func (filter *SwearFilter) phoneticCandidate(message string, span [2]int, swear string, rule matchRule) bool {
+	token := message[span[0]:span[1]]
+	written := token
+	if rule.source != nil {
+		start, end := rule.source.span(span[0], span[1])
+		written = rule.original[start:end]
+	}
+	if utf8.RuneCountInString(written) < phoneticMinLength || strings.Contains(token, swear) {
+		return false
+	}
+	if initial := phoneticInitial(token); initial == 0 || initial != phoneticInitial(swear) {
+		return false
+	}
+	if _, ok := filter.allowed[token]; ok {
+		return false
+	}
+	for _, exception := range rule.exceptions {
+		if token == exception {
+			return false
+		}
+	}
+	return true
+}
+
+// phoneticInitial returns the letter a word starts with, once spellings of the same initial sound are merged (ex: ph
+// -> f, kn -> n), 0 if it doesn't start with an ASCII letter
+func phoneticInitial(word string) byte {
+	switch {
+	case word == "" || word[0] < 'a' || word[0] > 'z':
+		return 0
+	case strings.HasPrefix(word, "ph"):
+		return 'f'
+	case hasAnyPrefix(word, "gn", "kn", "pn", "wr", "ae"):
+		return word[1]
+	case word[0] == 'x':
+		return 's'
+	case word[0] == 'c' && len(word) > 1 && isFrontVowel(word[1]):
+		return 's'
+	case word[0] == 'c' && !strings.HasPrefix(word, "ch"), word[0] == 'q':
+		return 'k'
+	}
+	return word[0]
+}
+
+// phoneticMatches returns where the words of the wordlist were only found by how they sound in the normalized message,
+// when they don't trip it for lack of PhoneticBlocking; the filter's lock must be held
+func (filter *SwearFilter) phoneticMatches(msg string, message mappedText) (matches []Match) {
+	if filter.Phonetic == PhoneticNone || filter.PhoneticBlocking {
+		return nil
+	}
+	compiled := filter.compiled()
+	if len(compiled.phonetic) == 0 {
+		return nil
+	}
+	now := filter.now()
+	for _, span := range wordSpans(message.text) {
+		token := message.text[span[0]:span[1]]
+		for _, word := range compiled.phonetic[filter.Phonetic.Encode(token)] {
+			swear := compiled.automaton.words[word]
+			entry := filter.entries[swear]
+			if action, _ := filter.action(entry); action != ActionBlock || !filter.enabled(entry, scanOptions{}, now) {
+				continue
+			}
+			rule := filter.matchRule(swear).mapped(msg, &message)
+			rule.phonetic = filter.phoneticCode(swear)
+			if filter.phoneticMatch(message.text, span, swear, rule) {
+				start, end := message.span(span[0], span[1])
+				matches = append(matches, Match{Word: swear, Kind: MatchPhonetic, Start: start, End: end})
+			}
+		}
+	}
+	return matches
+}
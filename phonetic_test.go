@@ -0,0 +1,115 @@
+package swearfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPhoneticEncode(t *testing.T) {
+	tests := []struct {
+		algorithm PhoneticAlgorithm
+		input     string
+		expected  string
+	}{
+		{PhoneticMetaphone, "fuck", "FK"},
+		{PhoneticMetaphone, "phuk", "FK"},
+		{PhoneticMetaphone, "bitch", "BX"},
+		{PhoneticMetaphone, "biatch", "BX"},
+		{PhoneticMetaphone, "Shit", "XT"},
+		{PhoneticMetaphone, "knight", "NT"},
+		{PhoneticMetaphone, "thumb", "0M"},
+		{PhoneticMetaphone, "xylophone", "SLFN"},
+		{PhoneticSoundex, "robert", "R163"},
+		{PhoneticSoundex, "rupert", "R163"},
+		{PhoneticSoundex, "ashcraft", "A261"},
+		{PhoneticSoundex, "tymczak", "T522"},
+		{PhoneticSoundex, "biatch", "B320"},
+		{PhoneticSoundex, "a", "A000"},
+		{PhoneticMetaphone, "f4ck", ""},
+		{PhoneticNone, "fuck", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.algorithm.Encode(tt.input); got != tt.expected {
+			t.Errorf("%s got %q for %q, want %q", tt.algorithm, got, tt.input, tt.expected)
+		}
+	}
+}
+
+func TestPhonetic(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "bitch", "shit", "ass")
+
+	tests := []struct {
+		name      string
+		algorithm PhoneticAlgorithm
+		input     string
+		expected  []string
+	}{
+		{"off by default", PhoneticNone, "phuk you", []string{}},
+		{"metaphone", PhoneticMetaphone, "phuk you", []string{"fuck"}},
+		{"metaphone biatch", PhoneticMetaphone, "up yours biatch", []string{"bitch"}},
+		{"soundex", PhoneticSoundex, "up yours biatch", []string{"bitch"}},
+		{"too short", PhoneticMetaphone, "fog today", []string{}},
+		{"too short soundex", PhoneticSoundex, "ace", []string{}},
+		{"other first letter", PhoneticSoundex, "vuck", []string{}},
+	}
+	filter.PhoneticBlocking = true
+	for _, tt := range tests {
+		filter.Phonetic = tt.algorithm
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("%s: Check failed: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(trippers, tt.expected) {
+			t.Errorf("%s: got trippers %v, want %v", tt.name, trippers, tt.expected)
+		}
+	}
+
+	//Without PhoneticBlocking, words that merely sound alike are reported but neither tripped nor censored
+	filter.PhoneticBlocking = false
+	innocent := []string{"fake news", "fog today", "foggy", "the beach", "nice shot", "a clean sheet", "the face", "phuk you"}
+	for _, algorithm := range []PhoneticAlgorithm{PhoneticMetaphone, PhoneticSoundex} {
+		filter.Phonetic = algorithm
+		for _, input := range innocent {
+			if trippers, err := filter.Check(input); err != nil || len(trippers) != 0 {
+				t.Errorf("%v: Check(%q) got %v, %v, want nothing", algorithm, input, trippers, err)
+			}
+			if censored, _, err := filter.Censor(input); err != nil || censored != input {
+				t.Errorf("%v: Censor(%q) got %q, %v, want it unchanged", algorithm, input, censored, err)
+			}
+		}
+	}
+}
+
+func TestPhoneticDetailed(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.Phonetic = PhoneticMetaphone
+
+	for _, blocking := range []bool{false, true} {
+		filter.PhoneticBlocking = blocking
+		matches, err := filter.CheckDetailed("oh phuk")
+		if err != nil {
+			t.Fatalf("CheckDetailed failed: %v", err)
+		}
+		want := []Match{{Word: "fuck", Kind: MatchPhonetic, Start: 3, End: 7}}
+		if !reflect.DeepEqual(matches, want) {
+			t.Errorf("blocking %t: got matches %+v, want %+v", blocking, matches, want)
+		}
+	}
+
+	matches, err := filter.CheckDetailed("fuck, or phuk")
+	if err != nil {
+		t.Fatalf("CheckDetailed failed: %v", err)
+	}
+	want := []Match{{Word: "fuck", Kind: MatchPlain, Start: 0, End: 4}, {Word: "fuck", Kind: MatchPhonetic, Start: 9, End: 13}}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("got matches %+v, want %+v", matches, want)
+	}
+
+	if matched, match, _ := filter.TestWord("fuck", "phuk"); !matched || match.Kind != MatchPhonetic {
+		t.Errorf("got %t, %+v from TestWord, want a phonetic match", matched, match)
+	}
+	filter.PhoneticBlocking = false
+	if matched, _, _ := filter.TestWord("fuck", "phuk"); matched {
+		t.Errorf("got a match from TestWord without PhoneticBlocking, want none")
+	}
+}
@@ -0,0 +1,179 @@
+package swearfilter
+
+import (
+	"regexp"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// PIIKind is the kind of personal information found by the PII detectors
+type PIIKind int
+
+// Kinds of personal information the PII detectors look for
+const (
+	PIIEmail PIIKind = iota + 1
+	PIIPhone
+	PIICreditCard
+)
+
+// String returns a lowercase name for the kind
+func (kind PIIKind) String() string {
+	switch kind {
+	case PIIEmail:
+		return "email"
+	case PIIPhone:
+		return "phone"
+	case PIICreditCard:
+		return "credit card"
+	}
+	return "unknown"
+}
+
+// piiKind returns the kind named name, PII tripping a message under the name of its kind
+func piiKind(name string) (PIIKind, bool) {
+	for kind := PIIEmail; kind <= PIICreditCard; kind++ {
+		if kind.String() == name {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// PIIMatch is a piece of personal information found in a message
+type PIIMatch struct {
+	Kind  PIIKind
+	Text  string //The matched text, as it appears in the message
+	Start int    //Byte offset of the match in the message
+	End   int    //Byte offset just past the match in the message
+}
+
+var (
+	regexEmail      = regexp.MustCompile(`[\p{L}\p{Nd}._%+-]+[@＠][\p{L}\p{Nd}-]+(?:\.[\p{L}\p{Nd}-]+)*\.\p{L}{2,}`)
+	regexCreditCard = regexp.MustCompile(`\p{Nd}(?:[ -]?\p{Nd}){12,18}`)
+	regexPhone      = regexp.MustCompile(`(?:\+\p{Nd}{1,3}[ .-]?)?(?:\(\p{Nd}{2,4}\)|\p{Nd}{2,4})[ .-]?\p{Nd}{3,4}[ .-]?\p{Nd}{3,4}`)
+)
+
+// FindPII returns the email addresses, phone numbers and Luhn-valid card numbers found in msg, ordered by position
+func FindPII(msg string) (matches []PIIMatch) {
+	taken := func(start, end int) bool {
+		for _, match := range matches {
+			if start < match.End && end > match.Start {
+				return true
+			}
+		}
+		return false
+	}
+	find := func(kind PIIKind, regex *regexp.Regexp, valid func(digits string) bool) {
+		for _, loc := range regex.FindAllStringIndex(msg, -1) {
+			if kind != PIIEmail && (digitAt(msg, loc[0], -1) || digitAt(msg, loc[1], 1)) {
+				continue //Part of a longer number
+			}
+			if taken(loc[0], loc[1]) || (valid != nil && !valid(asciiDigits(msg[loc[0]:loc[1]]))) {
+				continue
+			}
+			matches = append(matches, PIIMatch{Kind: kind, Text: msg[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+		}
+	}
+
+	find(PIIEmail, regexEmail, nil)
+	find(PIICreditCard, regexCreditCard, luhn)
+	find(PIIPhone, regexPhone, func(digits string) bool {
+		return len(digits) >= 7 && len(digits) <= 15
+	})
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Start < matches[j].Start
+	})
+	return matches
+}
+
+// findPII returns the personal information found in msg once lookalike characters are folded by the Punycode and
+// Confusables stages of the filter's pipeline, with offsets and text mapped back into msg; the other stages would turn
+// digits into letters or collapse them; the filter's lock must be held
+func (filter *SwearFilter) findPII(msg string) []PIIMatch {
+	pipeline := make([]Normalizer, 0, 2)
+	if !filter.DisablePunycodeDecoding {
+		pipeline = append(pipeline, PunycodeNormalizer{})
+	}
+	if !filter.DisableConfusableFolding {
+		pipeline = append(pipeline, ConfusablesNormalizer{})
+	}
+	//Neither stage can fail
+	mapped, _ := filter.normalizeMapped(msg, scanOptions{normalizers: pipeline})
+	defer mapped.release()
+
+	matches := FindPII(mapped.text)
+	for i, match := range matches {
+		start, end := mapped.span(match.Start, match.End)
+		matches[i] = PIIMatch{Kind: match.Kind, Text: msg[start:end], Start: start, End: end}
+	}
+	return matches
+}
+
+// piiKinds returns the kinds of the matches, each once and in PIIKind order
+func piiKinds(matches []PIIMatch) (kinds []PIIKind) {
+	for kind := PIIEmail; kind <= PIICreditCard; kind++ {
+		for _, match := range matches {
+			if match.Kind == kind {
+				kinds = append(kinds, kind)
+				break
+			}
+		}
+	}
+	return kinds
+}
+
+// digitAt reports whether a digit sits just before (dir < 0) or at (dir > 0) offset i, looking past one separator
+func digitAt(msg string, i, dir int) bool {
+	for separators := 0; separators < 2; separators++ {
+		var r rune
+		var size int
+		if dir < 0 {
+			r, size = utf8.DecodeLastRuneInString(msg[:i])
+			i -= size
+		} else {
+			r, size = utf8.DecodeRuneInString(msg[i:])
+			i += size
+		}
+		if unicode.IsDigit(r) {
+			return true
+		}
+		if r != ' ' && r != '-' && r != '.' {
+			return false
+		}
+	}
+	return false
+}
+
+// asciiDigits returns the digits of s after folding compatibility forms such as fullwidth digits
+func asciiDigits(s string) string {
+	digits := make([]byte, 0, len(s))
+	for _, c := range []byte(norm.NFKC.String(s)) {
+		if c >= '0' && c <= '9' {
+			digits = append(digits, c)
+		}
+	}
+	return string(digits)
+}
+
+// luhn reports whether digits is a plausible card number that passes the Luhn checksum
+func luhn(digits string) bool {
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,92 @@
+package swearfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindPII(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []PIIKind
+	}{
+		{"email", "mail me at john.doe+spam@example.co.uk ok", []PIIKind{PIIEmail}},
+		{"phone", "call (555) 123-4567 tonight", []PIIKind{PIIPhone}},
+		{"intl phone", "ring +44 20 7946 0958", []PIIKind{PIIPhone}},
+		{"card", "card 4111 1111 1111 1111 exp 12/30", []PIIKind{PIICreditCard}},
+		{"fullwidth card", "４１１１１１１１１１１１１１１１", []PIIKind{PIICreditCard}},
+		{"bad luhn", "order 4111 1111 1111 1112", nil},
+		{"date", "see you 2024-01-01", nil},
+		{"long number", "id 123456789012345678901234", nil},
+		{"mixed", "a@b.io or 555.123.4567", []PIIKind{PIIEmail, PIIPhone}},
+		{"clean", "nothing to see here", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := FindPII(tt.input)
+			if len(matches) != len(tt.expected) {
+				t.Fatalf("got matches %v, want kinds %v", matches, tt.expected)
+			}
+			for i, match := range matches {
+				if match.Kind != tt.expected[i] {
+					t.Errorf("got kind %s, want %s", match.Kind, tt.expected[i])
+				}
+				if tt.input[match.Start:match.End] != match.Text {
+					t.Errorf("got offsets %d-%d for %q, want them to cover the text", match.Start, match.End, match.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestInspectPII(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	result, err := filter.Inspect("fuck you, email me@example.com")
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	if len(result.Words) != 1 || result.PII != nil {
+		t.Errorf("got result %+v with PII detection disabled, want only words", result)
+	}
+
+	filter.DetectPII = true
+	result, _ = filter.Inspect("fuck you, email me@example.com")
+	if len(result.PII) != 1 || result.PII[0].Text != "me@example.com" {
+		t.Errorf("got PII %v, want the email address", result.PII)
+	}
+}
+
+func TestCensorPII(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	msg := "fuck off, mail me@example.com or call 555-123-4567"
+	if censored, _, _ := filter.Censor(msg); censored != "**** off, mail me@example.com or call 555-123-4567" {
+		t.Errorf("got %q with PII detection disabled, want only the word censored", censored)
+	}
+
+	filter.DetectPII = true
+	censored, trippedWords, err := filter.Censor(msg)
+	if err != nil {
+		t.Fatalf("Censor failed: %v", err)
+	}
+	if censored != "**** off, mail ************** or call ************" {
+		t.Errorf("got %q, want the email address and phone number censored too", censored)
+	}
+	if !reflect.DeepEqual(trippedWords, []string{"fuck", "email", "phone"}) {
+		t.Errorf("got %v, want the word and the kinds of PII", trippedWords)
+	}
+
+	//Fullwidth forms are folded by the normalization pipeline before looking for PII
+	msg = "write to \uff4d\uff45\uff20\uff45\uff58\uff41\uff4d\uff50\uff4c\uff45\uff0e\uff43\uff4f\uff4d"
+	trippedWords, err = filter.Check(msg)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if !reflect.DeepEqual(trippedWords, []string{"email"}) {
+		t.Errorf("got %v, want the fullwidth email address", trippedWords)
+	}
+	matches, _ := filter.CheckDetailed(msg)
+	if len(matches) != 1 || matches[0].Kind != MatchPII || msg[matches[0].Start:matches[0].End] != msg[len("write to "):] {
+		t.Errorf("got matches %+v, want the email address mapped back into the message", matches)
+	}
+}
@@ -0,0 +1,183 @@
+package swearfilter
+
+// Action is what a matching entry does to a message
+type Action int
+
+// Actions an entry can take, an entry added without one is ActionDefault
+const (
+	ActionDefault Action = iota //Takes the action of the entry's category from CategoryActions, or blocks
+	ActionBlock                 //Trips the message
+	ActionShadow                //Monitor-only, the same as setting WordOptions.Shadow
+	ActionAllow                 //Lets the entries found inside it through even if they would trip the message, depending on Precedence
+)
+
+// String returns a lowercase name for the action
+func (action Action) String() string {
+	switch action {
+	case ActionDefault:
+		return "default"
+	case ActionBlock:
+		return "block"
+	case ActionShadow:
+		return "shadow"
+	case ActionAllow:
+		return "allow"
+	}
+	return "unknown"
+}
+
+// RuleKind is where the action of a matching entry came from, used to rank entries against each other in Precedence
+type RuleKind int
+
+// Kinds of rules a matching entry can be
+const (
+	RuleAllow    RuleKind = iota //An entry explicitly set to ActionAllow
+	RuleBlock                    //An entry explicitly set to ActionBlock, or without an action or category action, and co-occurrence rules
+	RuleShadow                   //An entry explicitly set to ActionShadow or Shadow
+	RuleCategory                 //An entry taking its action from CategoryActions
+)
+
+// String returns a lowercase name for the kind
+func (kind RuleKind) String() string {
+	switch kind {
+	case RuleAllow:
+		return "allow"
+	case RuleBlock:
+		return "block"
+	case RuleShadow:
+		return "shadow"
+	case RuleCategory:
+		return "category"
+	}
+	return "unknown"
+}
+
+// DefaultPrecedence is the order rules win in when Precedence isn't set: explicit allows beat explicit blocks, which
+// beat category defaults, and monitor-only entries never change the outcome
+var DefaultPrecedence = []RuleKind{RuleAllow, RuleBlock, RuleCategory, RuleShadow}
+
+// Decision is the outcome of a check and the rule that decided it
+type Decision struct {
+	Action Action   //ActionBlock if the message tripped, ActionAllow or ActionShadow if a rule of that action won, ActionDefault if nothing matched
+	Kind   RuleKind //Kind of the winning rule
+	Rule   string   //The word or co-occurrence rule name that won, the first in order when several of the same kind matched
+}
+
+// candidate is an entry found in a message, waiting for the precedence to decide between them
+type candidate struct {
+	rule   string
+	action Action
+	kind   RuleKind
+}
+
+// action returns the action the entry takes when matched and where it came from, the filter's lock must be held
+func (filter *SwearFilter) action(opts WordOptions) (Action, RuleKind) {
+	switch {
+	case opts.Action == ActionShadow || opts.Shadow:
+		return ActionShadow, RuleShadow
+	case opts.Action == ActionBlock:
+		return ActionBlock, RuleBlock
+	case opts.Action == ActionAllow:
+		return ActionAllow, RuleAllow
+	}
+	if action := filter.CategoryActions[opts.Category]; opts.Category != "" && action != ActionDefault {
+		return action, RuleCategory
+	}
+	return ActionBlock, RuleBlock
+}
+
+// overrule drops the candidates that were only found inside the matches of allow candidates ranking above them, the
+// same way allowed words only excuse the matches inside them; co-occurrence rules have no place in the message and are
+// never dropped; the filter's lock must be held
+func (filter *SwearFilter) overrule(msg string, mapped mappedText, candidates []candidate, opts scanOptions) []candidate {
+	allows := 0
+	words := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if c.action == ActionAllow {
+			allows++
+		}
+		words = append(words, c.rule)
+	}
+	if allows == 0 || allows == len(candidates) {
+		return candidates
+	}
+
+	spans := make(map[string][]Match)
+	for _, match := range filter.findWords(msg, mapped, words, opts) {
+		spans[match.Word] = append(spans[match.Word], match)
+	}
+	inside := func(match Match, rank int) bool {
+		for _, c := range candidates {
+			if c.action != ActionAllow || filter.rank(c.kind) >= rank {
+				continue
+			}
+			for _, allow := range spans[c.rule] {
+				if match.Start < allow.End && allow.Start < match.End {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	kept := candidates[:0:0]
+	for _, c := range candidates {
+		excused := c.action != ActionAllow && len(spans[c.rule]) > 0
+		for _, match := range spans[c.rule] {
+			if excused && !inside(match, filter.rank(c.kind)) {
+				excused = false
+			}
+		}
+		if !excused {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// decide picks the candidate that wins under the filter's precedence, the filter's lock must be held
+//
+// Allow candidates only win when nothing blocks the message, as they have already excused what was found inside them.
+func (filter *SwearFilter) decide(candidates []candidate) (decision Decision) {
+	if decision = filter.best(candidates, false); decision.Action == ActionBlock {
+		return decision
+	}
+	return filter.best(candidates, true)
+}
+
+// best picks the candidate that ranks first in the filter's precedence, skipping allow candidates unless allow is set,
+// the filter's lock must be held
+func (filter *SwearFilter) best(candidates []candidate, allow bool) (decision Decision) {
+	best := -1
+	for _, c := range candidates {
+		if c.action == ActionAllow && !allow {
+			continue
+		}
+		rank := filter.rank(c.kind)
+		if best < 0 || rank < best || (rank == best && c.rule < decision.Rule) {
+			best = rank
+			decision = Decision{Action: c.action, Kind: c.kind, Rule: c.rule}
+		}
+	}
+	return decision
+}
+
+// rank returns the position of kind in the precedence, kinds missing from Precedence rank after the listed ones in
+// DefaultPrecedence order
+func (filter *SwearFilter) rank(kind RuleKind) int {
+	precedence := filter.Precedence
+	if precedence == nil {
+		precedence = DefaultPrecedence
+	}
+	for i, k := range precedence {
+		if k == kind {
+			return i
+		}
+	}
+	for i, k := range DefaultPrecedence {
+		if k == kind {
+			return len(precedence) + i
+		}
+	}
+	return len(precedence) + len(DefaultPrecedence)
+}
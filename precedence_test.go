@@ -0,0 +1,68 @@
+package swearfilter
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPrecedence(t *testing.T) {
+	filter := NewSwearFilter(false, "cunt", "fuck")
+	filter.AddWithOptions(WordOptions{Action: ActionAllow}, "scunthorpe")
+	filter.AddWithOptions(WordOptions{Category: "slang"}, "sus")
+	filter.AddWithOptions(WordOptions{Shadow: true}, "cunthorpe")
+	filter.CategoryActions = map[string]Action{"slang": ActionShadow}
+
+	tests := []struct {
+		name       string
+		precedence []RuleKind
+		input      string
+		tripped    int
+		expected   Decision
+	}{
+		{"block", nil, "cunt", 1, Decision{Action: ActionBlock, Kind: RuleBlock, Rule: "cunt"}},
+		{"explicit allow wins", nil, "scunthorpe", 0, Decision{Action: ActionAllow, Kind: RuleAllow, Rule: "scunthorpe"}},
+		{"category default", nil, "sus", 0, Decision{Action: ActionShadow, Kind: RuleCategory, Rule: "sus"}},
+		{"block beats category", nil, "sus cunt", 1, Decision{Action: ActionBlock, Kind: RuleBlock, Rule: "cunt"}},
+		{"nothing", nil, "hello", 0, Decision{}},
+		{"allow is local", nil, "scunthorpe: fuck you", 1, Decision{Action: ActionBlock, Kind: RuleBlock, Rule: "fuck"}},
+		{"allow outside", nil, "scunthorpe, cunt", 1, Decision{Action: ActionBlock, Kind: RuleBlock, Rule: "cunt"}},
+		{"block first", []RuleKind{RuleBlock}, "scunthorpe", 1, Decision{Action: ActionBlock, Kind: RuleBlock, Rule: "cunt"}},
+		{"shadow first", []RuleKind{RuleShadow, RuleBlock}, "cunthorpe", 0, Decision{Action: ActionShadow, Kind: RuleShadow, Rule: "cunthorpe"}},
+		{"category first", []RuleKind{RuleCategory}, "sus cunt", 0, Decision{Action: ActionShadow, Kind: RuleCategory, Rule: "sus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter.Precedence = tt.precedence
+			result, err := filter.Inspect(tt.input)
+			if err != nil {
+				t.Fatalf("Inspect failed: %v", err)
+			}
+			if len(result.Words) != tt.tripped {
+				t.Errorf("got words %v, want %d", result.Words, tt.tripped)
+			}
+			if result.Decision != tt.expected {
+				t.Errorf("got decision %+v, want %+v", result.Decision, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrecedenceAllowCensor(t *testing.T) {
+	filter := NewSwearFilter(false, "cunt", "fuck")
+	filter.AddWithOptions(WordOptions{Action: ActionAllow}, "scunthorpe")
+
+	censored, trippedWords, err := filter.Censor("Scunthorpe: fuck you")
+	if err != nil {
+		t.Fatalf("Censor failed: %v", err)
+	}
+	if censored != "Scunthorpe: **** you" || len(trippedWords) != 1 || trippedWords[0] != "fuck" {
+		t.Errorf("got %q and %v, want only the word outside the allowed one censored", censored, trippedWords)
+	}
+
+	words := filter.Words()
+	sort.Strings(words)
+	if !reflect.DeepEqual(words, []string{"cunt", "fuck"}) {
+		t.Errorf("got words %v, want the allowed word left out", words)
+	}
+}
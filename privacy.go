@@ -0,0 +1,43 @@
+package swearfilter
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// event builds the MatchEvent passed to hooks, leaving out the message content in privacy mode; spans holds where
+// each word was first found, the filter's lock must be held
+func (filter *SwearFilter) event(word, msg string, shadow bool, spans map[string]Span) MatchEvent {
+	event := MatchEvent{Word: word, Shadow: shadow, Category: filter.entries[word].Category}
+	if p, ok := filter.patterns[word]; ok {
+		event.Category = p.opts.Category
+	}
+	if span, ok := spans[word]; ok {
+		event.Start, event.End = span.Start, span.End
+	}
+	if filter.PrivacyMode {
+		event.MessageHash = filter.hashMessage(msg)
+	} else {
+		event.Message = msg
+	}
+	return event
+}
+
+// hashMessage returns a hex-encoded HMAC-SHA256 of msg keyed with the filter's privacy salt
+func (filter *SwearFilter) hashMessage(msg string) string {
+	salt := filter.PrivacySalt
+	if len(salt) == 0 {
+		filter.saltOnce.Do(func() {
+			filter.randomSalt = make([]byte, 32)
+			if _, err := rand.Read(filter.randomSalt); err != nil {
+				panic("swearfilter: unable to generate privacy salt: " + err.Error())
+			}
+		})
+		salt = filter.randomSalt
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(msg))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,57 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestPrivacyMode(t *testing.T) {
+	filter := NewSwearFilter(false)
+	filter.AddWithOptions(WordOptions{Shadow: true}, "darn")
+	filter.PrivacyMode = true
+	filter.PrivacySalt = []byte("pepper")
+
+	var events []MatchEvent
+	filter.OnShadowMatch = func(event MatchEvent) {
+		events = append(events, event)
+	}
+	filter.Check("darn it")
+	filter.Check("darn it")
+	filter.Check("darn you")
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want %d", len(events), 3)
+	}
+	for _, event := range events {
+		if event.Message != "" {
+			t.Errorf("got message %q in privacy mode, want none", event.Message)
+		}
+		if len(event.MessageHash) != 64 {
+			t.Errorf("got message hash %q, want a hex SHA-256", event.MessageHash)
+		}
+		if event.Start != 0 || event.End != 4 {
+			t.Errorf("got offsets %d-%d, want those of darn", event.Start, event.End)
+		}
+	}
+	if events[0].MessageHash != events[1].MessageHash || events[0].MessageHash == events[2].MessageHash {
+		t.Errorf("got hashes %s, %s and %s, want only the first two equal", events[0].MessageHash, events[1].MessageHash, events[2].MessageHash)
+	}
+
+	other := NewSwearFilter(false)
+	other.PrivacyMode = true
+	if other.hashMessage("darn it") == events[0].MessageHash {
+		t.Errorf("got the same hash with a random salt, want a different one")
+	}
+
+	var matches []MatchEvent
+	filter.OnMatch = func(event MatchEvent) {
+		matches = append(matches, event)
+	}
+	filter.AddWithOptions(WordOptions{Category: "profanity"}, "fuck")
+	filter.Check("darn it, fuck")
+	if len(matches) != 1 || matches[0].Message != "" || len(matches[0].MessageHash) != 64 {
+		t.Errorf("got match events %+v, want one without the message but with its hash", matches)
+	}
+	if len(matches) == 1 && (matches[0].Start != 9 || matches[0].End != 13 || matches[0].Category != "profanity") {
+		t.Errorf("got match event %+v, want the offsets and category of the word", matches[0])
+	}
+}
@@ -0,0 +1,143 @@
+package swearfilter
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Punycode parameters from RFC 3492
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+	punycodePrefix      = "xn--"
+)
+
+var errInvalidPunycode = errors.New("swearfilter: invalid punycode")
+
+var regexPunycodeLabel = regexp.MustCompile(`(?i)xn--[a-z0-9-]+`)
+
+// decodePunycodeLabels replaces every xn-- label in s with its Unicode form, leaving labels that fail to decode as they are
+func decodePunycodeLabels(s string) string {
+	if !strings.Contains(strings.ToLower(s), punycodePrefix) {
+		return s
+	}
+	return regexPunycodeLabel.ReplaceAllStringFunc(s, decodePunycodeLabel)
+}
+
+// decodePunycodeLabel decodes a single xn-- label, returning it unchanged if it isn't valid punycode
+func decodePunycodeLabel(label string) string {
+	decoded, err := decodePunycode(strings.ToLower(label[len(punycodePrefix):]))
+	if err != nil {
+		return label
+	}
+	return decoded
+}
+
+// foldHost decodes the punycode labels of host and folds it down to the base letters an internationalized
+// domain could be imitating, including lookalikes from other scripts
+func foldHost(host string) string {
+	host = decodePunycodeLabels(host)
+	folded, _, err := transform.String(transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), host)
+	if err != nil {
+		return host
+	}
+	return foldConfusables(folded)
+}
+
+// decodePunycode decodes a single punycode label with its xn-- prefix removed, as described in RFC 3492
+func decodePunycode(encoded string) (string, error) {
+	n, i, bias := punycodeInitialN, 0, punycodeInitialBias
+
+	var output []rune
+	if pos := strings.LastIndexByte(encoded, '-'); pos >= 0 {
+		for _, c := range []byte(encoded[:pos]) {
+			if c >= 0x80 {
+				return "", errInvalidPunycode
+			}
+			output = append(output, rune(c))
+		}
+		encoded = encoded[pos+1:]
+	}
+
+	for len(encoded) > 0 {
+		oldi, w := i, 1
+		for k := punycodeBase; ; k += punycodeBase {
+			if len(encoded) == 0 {
+				return "", errInvalidPunycode
+			}
+			digit := punycodeDigit(encoded[0])
+			encoded = encoded[1:]
+			if digit < 0 || digit > (unicode.MaxRune-i)/w {
+				return "", errInvalidPunycode
+			}
+			i += digit * w
+
+			t := k - bias
+			if t < punycodeTMin {
+				t = punycodeTMin
+			} else if t > punycodeTMax {
+				t = punycodeTMax
+			}
+			if digit < t {
+				break
+			}
+			w *= punycodeBase - t
+			if w > unicode.MaxRune {
+				return "", errInvalidPunycode
+			}
+		}
+
+		length := len(output) + 1
+		bias = punycodeAdapt(i-oldi, length, oldi == 0)
+		n += i / length
+		i %= length
+		if n > unicode.MaxRune {
+			return "", errInvalidPunycode
+		}
+
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+	return string(output), nil
+}
+
+func punycodeDigit(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a')
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A')
+	}
+	return -1
+}
+
+func punycodeAdapt(delta, length int, first bool) int {
+	if first {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / length
+
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
@@ -0,0 +1,52 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestDecodePunycode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"mnchen-3ya", "münchen"},
+		{"bcher-kva", "bücher"},
+		{"fuk-5ed", "fuсk"},
+		{"-> $1.00 <--", "-> $1.00 <-"},
+	}
+	for _, tt := range tests {
+		got, err := decodePunycode(tt.input)
+		if err != nil {
+			t.Errorf("decodePunycode(%q) failed: %v", tt.input, err)
+		}
+		if got != tt.expected {
+			t.Errorf("decodePunycode(%q) got %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+
+	for _, input := range []string{"abc-99999999999", "a-!", "bä-kva"} {
+		if got, err := decodePunycode(input); err == nil {
+			t.Errorf("decodePunycode(%q) got %q, want error", input, got)
+		}
+	}
+	if got := decodePunycodeLabels("visit xn--mnchen-3ya.de or xn--!"); got != "visit münchen.de or xn--!" {
+		t.Errorf("got %q, want only valid labels decoded", got)
+	}
+}
+
+func TestPunycodeChecks(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	if trippers, _ := filter.Check("xn--fck-hoa"); len(trippers) != 1 {
+		t.Errorf("got trippers %v, want the decoded label to trip", trippers)
+	}
+	filter.DisablePunycodeDecoding = true
+	if trippers, _ := filter.Check("xn--fck-hoa"); len(trippers) != 0 {
+		t.Errorf("got trippers %v with decoding disabled, want none", trippers)
+	}
+
+	filter.AddDomain("example.com")
+	links := filter.CheckLinks("see http://www.xn--exmple-cua.com/login")
+	if len(links) != 1 || links[0].Host != "www.exämple.com" || links[0].Domain != "example.com" {
+		t.Errorf("got links %v, want the IDN lookalike to match example.com", links)
+	}
+}
@@ -0,0 +1,56 @@
+package swearfilter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// repeatRunLength is how many times a character has to be repeated in a row for the run to count as elongation,
+// shorter runs are left alone as the doubled letters of ordinary words
+const repeatRunLength = 3
+
+// collapseRepeats returns the message with every run of repeatRunLength or more of the same character collapsed
+// down to one and, separated by InterpretationSeparator, down to two, so that both "fuuuuck" (fuck) and "asssss"
+// (ass) are caught; messages without such runs are returned unchanged
+func collapseRepeats(message mappedText) mappedText {
+	if !hasRepeatRun(message.text) {
+		return message
+	}
+	collapse := func(keep int) mappedText {
+		return message.mapSegments(repeatRun, func(run string) string {
+			r, size := utf8.DecodeRuneInString(run)
+			if r == ' ' || len(run) < repeatRunLength*size {
+				return run
+			}
+			return strings.Repeat(run[:size], keep)
+		})
+	}
+	return joinMapped([]mappedText{collapse(1), collapse(2)}, InterpretationSeparator)
+}
+
+// repeatRun returns the length of the run of the same character s starts with
+func repeatRun(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	n := size
+	for n < len(s) {
+		next, nextSize := utf8.DecodeRuneInString(s[n:])
+		if next != r || nextSize != size {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// hasRepeatRun reports whether s has a run of repeatRunLength or more of the same character, other than spaces
+func hasRepeatRun(s string) bool {
+	for i := 0; i < len(s); {
+		n := repeatRun(s[i:])
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r != ' ' && n >= repeatRunLength*size {
+			return true
+		}
+		i += n
+	}
+	return false
+}
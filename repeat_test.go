@@ -0,0 +1,59 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestRepeatCollapse(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "shit", "ass", "boob")
+
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"fuuuuuck", 1},
+		{"shiiiiit", 1},
+		{"SHIIIT", 1},
+		{"asssssss", 1},
+		{"booooob", 1},
+		{"füüüück", 1},
+		{"fuuck", 0},
+		{"shiitake", 0},
+		{"good", 0},
+		{"ooooh", 0},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if len(trippers) != tt.expected {
+			t.Errorf("got trippers %v for %q, want %d", trippers, tt.input, tt.expected)
+		}
+	}
+
+	if censored, _, _ := filter.Censor("oh fuuuuuck"); censored != "oh ********" {
+		t.Errorf("got %q, want the whole elongated word censored", censored)
+	}
+
+	filter.DisableRepeatCollapse = true
+	if trippers, _ := filter.Check("fuuuuuck"); len(trippers) != 0 {
+		t.Errorf("got trippers %v with collapsing disabled, want none", trippers)
+	}
+	if normalized, _ := filter.Normalize("sooooo"); normalized != "sooooo" {
+		t.Errorf("got %q with collapsing disabled, want the message unchanged", normalized)
+	}
+	filter.DisableRepeatCollapse = false
+	if normalized, _ := filter.Normalize("sooooo goood"); normalized != "so god"+InterpretationSeparator+"soo good" {
+		t.Errorf("got %q, want both collapsed interpretations", normalized)
+	}
+
+	//The spaced bypass must not join the end of one interpretation to the start of the next (t i + tt ii)
+	filter = NewSwearFilter(true, "tit")
+	if trippers, _ := filter.Check("ttt iii"); len(trippers) != 0 {
+		t.Errorf("got trippers %v, want no match across interpretations", trippers)
+	}
+	if trippers, _ := filter.Check("ttt iii ttt"); len(trippers) != 1 {
+		t.Errorf("got trippers %v, want tit spaced out within an interpretation", trippers)
+	}
+}
@@ -0,0 +1,107 @@
+package swearfilter
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// CoOccurrence is a compound rule that trips when two terms appear close to each other in a message, for
+// combinations of individually innocent words
+type CoOccurrence struct {
+	Name   string //Reported among the tripped words when the rule trips, defaults to "<A> near <B>"
+	A, B   string //The terms, each matching any token of the normalized message that contains it
+	Window int    //Maximum number of tokens between the two terms, 0 means they must be adjacent
+}
+
+// AddRule adds co-occurrence rules to the filter, replacing any existing rule with the same name
+func (filter *SwearFilter) AddRule(rules ...CoOccurrence) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	filter.addRules(rules)
+}
+
+// addRules adds co-occurrence rules to the filter, the filter's lock must be held
+func (filter *SwearFilter) addRules(rules []CoOccurrence) {
+	if filter.rules == nil {
+		filter.rules = make(map[string]CoOccurrence)
+	}
+	for _, rule := range rules {
+		rule.A, rule.B = strings.ToLower(rule.A), strings.ToLower(rule.B)
+		if rule.Name == "" {
+			rule.Name = rule.A + " near " + rule.B
+		}
+		filter.rules[rule.Name] = rule
+	}
+}
+
+// DeleteRule deletes the co-occurrence rules with the given names
+func (filter *SwearFilter) DeleteRule(names ...string) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	for _, name := range names {
+		delete(filter.rules, name)
+	}
+}
+
+// Rules returns the co-occurrence rules, sorted by name
+func (filter *SwearFilter) Rules() (rules []CoOccurrence) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	for _, rule := range filter.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	return rules
+}
+
+// trippedRules returns the names of the co-occurrence rules the normalized message trips, the filter's lock must be held
+//
+// Each interpretation of the message is tokenized on its own, so that terms at the end of one and the start of the
+// next aren't taken for neighbours.
+func (filter *SwearFilter) trippedRules(message string) (names []string) {
+	if len(filter.rules) == 0 {
+		return nil
+	}
+	var interpretations [][]string
+	for _, interpretation := range strings.Split(message, InterpretationSeparator) {
+		interpretations = append(interpretations, strings.FieldsFunc(interpretation, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}))
+	}
+
+	for name, rule := range filter.rules {
+		for _, tokens := range interpretations {
+			if rule.trips(tokens) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// trips reports whether both terms occur in tokens within the rule's window
+func (rule CoOccurrence) trips(tokens []string) bool {
+	lastA, lastB := -1, -1
+	for i, token := range tokens {
+		if strings.Contains(token, rule.A) {
+			if lastB >= 0 && i-lastB-1 <= rule.Window {
+				return true
+			}
+			lastA = i
+		}
+		if strings.Contains(token, rule.B) {
+			if lastA >= 0 && lastA != i && i-lastA-1 <= rule.Window {
+				return true
+			}
+			lastB = i
+		}
+	}
+	return false
+}
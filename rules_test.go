@@ -0,0 +1,48 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestCoOccurrence(t *testing.T) {
+	filter := NewSwearFilter(false)
+	filter.AddRule(CoOccurrence{A: "kill", B: "you", Window: 2}, CoOccurrence{Name: "doxx", A: "address", B: "leak"})
+	filter.AddRule(CoOccurrence{Name: "threat", A: "idiot", B: "kill"})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"adjacent", "I will kill you", []string{"kill near you"}},
+		{"reversed", "you, I'd kill", []string{"kill near you"}},
+		{"within window", "kill all of you", []string{"kill near you"}},
+		{"outside window", "kill the bugs, then thank you", []string{}},
+		{"inflected", "killing you softly", []string{"kill near you"}},
+		{"leet", "k!ll y0u", []string{"kill near you"}},
+		{"named", "address leak", []string{"doxx"}},
+		{"one term", "thank you", []string{}},
+		{"ambiguous", "idiot, kill!", []string{"threat"}},
+		{"across interpretations", "kill stuff idiot!", []string{}},
+		{"apart", "kill stuff idiot", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trippers, err := filter.Check(tt.input)
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if len(trippers) != len(tt.expected) || (len(trippers) > 0 && trippers[0] != tt.expected[0]) {
+				t.Errorf("got trippers %v, want %v", trippers, tt.expected)
+			}
+		})
+	}
+
+	if rules := filter.Rules(); len(rules) != 3 || rules[0].Name != "doxx" {
+		t.Errorf("got rules %v, want both sorted by name", rules)
+	}
+	filter.DeleteRule("doxx")
+	if trippers, _ := filter.Check("address leak"); len(trippers) != 0 {
+		t.Errorf("got trippers %v after deleting the rule, want none", trippers)
+	}
+}
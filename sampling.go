@@ -0,0 +1,30 @@
+package swearfilter
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+// sampled reports whether a check keyed by key should be inspected under the filter's SampleRate, counting the
+// outcome; the filter's lock must be held
+func (filter *SwearFilter) sampled(key string, now time.Time) bool {
+	rate := filter.SampleRate
+	if rate <= 0 || rate >= 1 {
+		return true
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	inspect := float64(h.Sum64()) < rate*math.MaxUint64
+
+	filter.stats.mutex.Lock()
+	bucket := filter.currentStats(now)
+	if inspect {
+		bucket.sampled++
+	} else {
+		bucket.skipped++
+	}
+	filter.stats.mutex.Unlock()
+	return inspect
+}
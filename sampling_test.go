@@ -0,0 +1,40 @@
+package swearfilter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSampling(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.SampleRate = 0.25
+
+	tripped := 0
+	for i := 0; i < 1000; i++ {
+		msg := fmt.Sprintf("fuck #%d", i)
+		first, err := filter.Check(msg)
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		second, _ := filter.Check(msg)
+		if len(first) != len(second) {
+			t.Errorf("got %v then %v for %q, want the same verdict", first, second, msg)
+		}
+		if len(first) > 0 {
+			tripped++
+		}
+	}
+	if tripped < 170 || tripped > 330 {
+		t.Errorf("got %d of 1000 messages inspected, want roughly %d", tripped, 250)
+	}
+
+	stats := filter.Stats()
+	if stats.Sampled != uint64(tripped*2) || stats.Sampled+stats.Skipped != 2000 {
+		t.Errorf("got %d sampled and %d skipped, want %d and %d", stats.Sampled, stats.Skipped, tripped*2, 2000-tripped*2)
+	}
+
+	filter.SampleRate = 0
+	if trippers, _ := filter.Check("fuck"); len(trippers) != 1 {
+		t.Errorf("got trippers %v with sampling off, want every message inspected", trippers)
+	}
+}
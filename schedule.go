@@ -0,0 +1,158 @@
+package swearfilter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule decides whether a word is active at a given time
+type Schedule interface {
+	Active(t time.Time) bool
+}
+
+// Window is a Schedule that is active between two times of day
+type Window struct {
+	Start    time.Duration  //Time of day the window opens, as an offset from midnight
+	End      time.Duration  //Time of day the window closes; if not after Start, the window runs past midnight
+	Days     []time.Weekday //Days the window opens on, or every day if empty
+	Location *time.Location //Time zone to evaluate the window in, or the checked time's own zone if nil
+}
+
+// Active reports whether t falls inside the window
+func (window Window) Active(t time.Time) bool {
+	if window.Location != nil {
+		t = t.In(window.Location)
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+
+	if window.Start < window.End {
+		return offset >= window.Start && offset < window.End && window.onDay(t.Weekday())
+	}
+	//The window wraps past midnight, so the early hours belong to the previous day's window
+	if offset >= window.Start {
+		return window.onDay(t.Weekday())
+	}
+	if offset < window.End {
+		return window.onDay((t.Weekday() + 6) % 7)
+	}
+	return false
+}
+
+func (window Window) onDay(day time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+	for _, d := range window.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Cron is a Schedule that is active during every minute matched by a cron expression
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// ParseCron parses a standard five-field cron expression (minute hour day-of-month month day-of-week)
+//
+// Fields accept *, numbers, ranges (a-b), lists (a,b) and steps (*/n, a-b/n). Like cron, when both day fields
+// are restricted a time matches if either of them does. For example "* 8-14 * * 1-5" is active on weekdays from
+// 08:00 to 14:59.
+func ParseCron(spec string) (*Cron, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("swearfilter: cron expression %q must have 5 fields, has %d", spec, len(fields))
+	}
+
+	cron := &Cron{}
+	var err error
+	if cron.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if cron.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if cron.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if cron.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if cron.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	//Both 0 and 7 mean Sunday
+	if cron.dow&(1<<7) != 0 {
+		cron.dow |= 1
+	}
+	cron.domAny = fields[2] == "*"
+	cron.dowAny = fields[4] == "*"
+	return cron, nil
+}
+
+// Active reports whether the minute t falls in is matched by the expression
+func (cron *Cron) Active(t time.Time) bool {
+	if cron.minute&(1<<uint(t.Minute())) == 0 || cron.hour&(1<<uint(t.Hour())) == 0 || cron.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := cron.dom&(1<<uint(t.Day())) != 0
+	dowMatch := cron.dow&(1<<uint(t.Weekday())) != 0
+	if cron.domAny || cron.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parseCronField returns the set of values matched by a single cron field as a bitset
+func parseCronField(field string, min, max int) (set uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("swearfilter: invalid step in cron field %q", field)
+			}
+			part = part[:i]
+		}
+
+		low, high := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			low, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("swearfilter: invalid value in cron field %q", field)
+			}
+			high = low
+			if len(bounds) == 2 {
+				high, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return 0, fmt.Errorf("swearfilter: invalid range in cron field %q", field)
+				}
+			} else if step > 1 {
+				high = max
+			}
+		}
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("swearfilter: cron field %q out of range %d-%d", field, min, max)
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+// now returns the current time according to the filter's clock, the filter's lock must be held
+func (filter *SwearFilter) now() time.Time {
+	if filter.Clock != nil {
+		return filter.Clock()
+	}
+	return time.Now()
+}
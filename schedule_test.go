@@ -0,0 +1,72 @@
+package swearfilter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	school := Window{Start: 8 * time.Hour, End: 15 * time.Hour, Days: []time.Weekday{time.Monday, time.Friday}}
+	night := Window{Start: 22 * time.Hour, End: 6 * time.Hour, Days: []time.Weekday{time.Friday}}
+
+	tests := []struct {
+		name     string
+		window   Window
+		at       time.Time
+		expected bool
+	}{
+		{"inside", school, time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC), true}, //Monday
+		{"before open", school, time.Date(2024, 1, 1, 7, 59, 0, 0, time.UTC), false},
+		{"at close", school, time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC), false},
+		{"wrong day", school, time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC), false},
+		{"wrap late", night, time.Date(2024, 1, 5, 23, 0, 0, 0, time.UTC), true},  //Friday
+		{"wrap early", night, time.Date(2024, 1, 6, 5, 0, 0, 0, time.UTC), true},  //Saturday morning
+		{"wrap wrong", night, time.Date(2024, 1, 5, 5, 0, 0, 0, time.UTC), false}, //Friday morning
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Active(tt.at); got != tt.expected {
+				t.Errorf("got active %t, want %t", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCron(t *testing.T) {
+	cron, err := ParseCron("*/15 8-14 * * 1-5")
+	if err != nil {
+		t.Fatalf("ParseCron failed: %v", err)
+	}
+	if !cron.Active(time.Date(2024, 1, 1, 8, 45, 0, 0, time.UTC)) {
+		t.Errorf("got inactive at 08:45 on a Monday, want active")
+	}
+	if cron.Active(time.Date(2024, 1, 1, 8, 46, 0, 0, time.UTC)) {
+		t.Errorf("got active at 08:46, want inactive")
+	}
+	if cron.Active(time.Date(2024, 1, 6, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("got active on a Saturday, want inactive")
+	}
+
+	for _, spec := range []string{"* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("got no error parsing %q, want one", spec)
+		}
+	}
+}
+
+func TestScheduledWord(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	filter := NewSwearFilter(false, "fuck")
+	filter.Clock = func() time.Time { return now }
+	filter.AddWithOptions(WordOptions{Schedule: Window{Start: 8 * time.Hour, End: 15 * time.Hour}}, "crap")
+
+	trippers, _ := filter.Check("fuck this crap")
+	if len(trippers) != 2 {
+		t.Errorf("got trippers %v during the window, want %d words", trippers, 2)
+	}
+	now = now.Add(8 * time.Hour)
+	trippers, _ = filter.Check("fuck this crap")
+	if len(trippers) != 1 || trippers[0] != "fuck" {
+		t.Errorf("got trippers %v outside the window, want %v", trippers, []string{"fuck"})
+	}
+}
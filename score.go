@@ -0,0 +1,79 @@
+package swearfilter
+
+// Severity is how offensive a word is, used to weigh it in Score
+type Severity int
+
+// Severity levels, a word added without one is SeverityDefault
+const (
+	SeverityDefault Severity = iota
+	SeverityMild
+	SeverityModerate
+	SeveritySevere
+)
+
+// String returns a lowercase name for the severity
+func (severity Severity) String() string {
+	switch severity {
+	case SeverityDefault:
+		return "default"
+	case SeverityMild:
+		return "mild"
+	case SeverityModerate:
+		return "moderate"
+	case SeveritySevere:
+		return "severe"
+	}
+	return "unknown"
+}
+
+// DefaultSeverityWeights are the weights Score uses for severities missing from ScoreWeights.Severity
+var DefaultSeverityWeights = map[Severity]float64{
+	SeverityDefault:  1,
+	SeverityMild:     0.5,
+	SeverityModerate: 1,
+	SeveritySevere:   2,
+}
+
+// ScoreWeights configures how Score weighs each tripped word
+type ScoreWeights struct {
+	Severity map[Severity]float64 //Base weight of a word per severity, DefaultSeverityWeights is used for missing levels
+	Category map[string]float64   //Multiplier applied to the base weight per category, 1 for missing categories
+}
+
+// Score checks msg and returns its toxicity score: the sum of the weights of every word it tripped
+//
+// A word's weight is its WordOptions.Weight if set, or else the weight of its severity multiplied by the weight of
+// its category, as configured in the filter's Weights.
+func (filter *SwearFilter) Score(msg string) (score float64, trippedWords []string, err error) {
+	trippedWords, err = filter.Check(msg)
+	if err != nil {
+		return 0, nil, err
+	}
+	return filter.score(trippedWords), trippedWords, nil
+}
+
+// score sums the weights of the given words
+func (filter *SwearFilter) score(words []string) (score float64) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	for _, word := range words {
+		score += filter.weight(filter.options(word))
+	}
+	return score
+}
+
+// weight returns how much a word with the given options adds to a score
+func (filter *SwearFilter) weight(opts WordOptions) float64 {
+	if opts.Weight > 0 {
+		return opts.Weight
+	}
+	weight, ok := filter.Weights.Severity[opts.Severity]
+	if !ok {
+		weight = DefaultSeverityWeights[opts.Severity]
+	}
+	if multiplier, ok := filter.Weights.Category[opts.Category]; ok {
+		weight *= multiplier
+	}
+	return weight
+}
@@ -0,0 +1,42 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	filter := NewSwearFilter(false, "heck")
+	filter.AddWithOptions(WordOptions{Severity: SeverityMild, Category: "profanity"}, "damn")
+	filter.AddWithOptions(WordOptions{Severity: SeveritySevere, Category: "harassment"}, "loser")
+	filter.AddWithOptions(WordOptions{Severity: SeveritySevere, Weight: 10}, "idiot")
+
+	tests := []struct {
+		name     string
+		weights  ScoreWeights
+		input    string
+		expected float64
+	}{
+		{"clean", ScoreWeights{}, "hello", 0},
+		{"defaults", ScoreWeights{}, "heck damn loser", 1 + 0.5 + 2},
+		{"severity weights", ScoreWeights{Severity: map[Severity]float64{SeverityMild: 0.1}}, "heck damn", 1 + 0.1},
+		{"category weights", ScoreWeights{Category: map[string]float64{"harassment": 3, "profanity": 0}}, "damn loser", 0 + 6},
+		{"word override", ScoreWeights{Category: map[string]float64{"": 0}}, "idiot heck", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter.Weights = tt.weights
+			score, trippers, err := filter.Score(tt.input)
+			if err != nil {
+				t.Fatalf("Score failed: %v", err)
+			}
+			if score != tt.expected {
+				t.Errorf("got score %v for %v, want %v", score, trippers, tt.expected)
+			}
+		})
+	}
+
+	filter.Weights = ScoreWeights{}
+	if result, _ := filter.Inspect("heck loser"); result.Score != 3 {
+		t.Errorf("got Inspect score %v, want %v", result.Score, 3)
+	}
+}
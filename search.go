@@ -0,0 +1,57 @@
+package swearfilter
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SearchResult is a word from the uhohwords list found by Search
+type SearchResult struct {
+	Word     string
+	Prefix   bool //Whether the word starts with the query
+	Distance int  //Edit distance between the query and the word
+}
+
+// Search looks up words that start with query or are spelled close to it, for autocomplete and "is something
+// like this already banned?" checks in moderator tooling
+//
+// Exact and prefix matches come first, shortest first, followed by approximate matches within an edit distance of
+// one per three runes of the query (but at least one), closest first. At most limit results are returned, or all of
+// them if limit is not positive.
+func (filter *SwearFilter) Search(query string, limit int) (results []SearchResult) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	maxDistance := utf8.RuneCountInString(query) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	filter.mutex.RLock()
+	for word := range filter.BadWords {
+		lower := strings.ToLower(word)
+		prefix := strings.HasPrefix(lower, query)
+		distance := levenshtein(query, lower)
+		if prefix || distance <= maxDistance {
+			results = append(results, SearchResult{Word: word, Prefix: prefix, Distance: distance})
+		}
+	}
+	filter.mutex.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Prefix != b.Prefix {
+			return a.Prefix
+		}
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		return a.Word < b.Word
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
@@ -0,0 +1,52 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"fuck", "fuck", 0},
+		{"fuck", "", 4},
+		{"fuck", "fcuk", 2},
+		{"kitten", "sitting", 3},
+		{"fück", "fuck", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.expected {
+			t.Errorf("levenshtein(%q, %q) got %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	filter := NewSwearFilter(false, "ass", "asshole", "assclown", "bass", "shit", "shitty", "shot")
+
+	results := filter.Search("Ass", 0)
+	expected := []string{"ass", "asshole", "assclown", "bass"}
+	if len(results) != len(expected) {
+		t.Fatalf("got results %v, want %v", results, expected)
+	}
+	for i, result := range results {
+		if result.Word != expected[i] {
+			t.Errorf("got %s at %d, want %s", result.Word, i, expected[i])
+		}
+	}
+	if results[0].Distance != 0 || !results[0].Prefix || results[3].Prefix {
+		t.Errorf("got results %v, want an exact prefix match first and the fuzzy match last", results)
+	}
+
+	if results := filter.Search("shut", 0); len(results) != 2 || results[0].Word != "shit" {
+		t.Errorf("got results %v, want shit and shot", results)
+	}
+	if results := filter.Search("sh", 2); len(results) != 2 {
+		t.Errorf("got %d results, want the limit of %d", len(results), 2)
+	}
+	if results := filter.Search("  ", 0); results != nil {
+		t.Errorf("got results %v for a blank query, want none", results)
+	}
+}
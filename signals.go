@@ -0,0 +1,61 @@
+package swearfilter
+
+import (
+	"unicode"
+)
+
+// shoutingMinLetters is how many cased letters a message needs before its shouting ratio is measured, so short
+// messages like "OK" or "LOL" aren't reported
+const shoutingMinLetters = 6
+
+// Signals holds auxiliary measurements of a message that moderation may want alongside its words, they never trip the
+// filter
+type Signals struct {
+	Shouting float64 //Fraction of cased letters that are uppercase, 0 for messages with too few letters to tell
+	Flood    int     //Longest run of the same character or emoji repeated back to back (ex: 5 for "nooooo")
+	Emoji    int     //Number of emoji and other pictographic symbols in the message
+}
+
+// DetectSignals measures the shouting and flooding signals of msg
+func DetectSignals(msg string) (signals Signals) {
+	var upper, cased, run int
+	var last rune = -1
+	for _, r := range msg {
+		if isEmojiModifier(r) {
+			continue
+		}
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+			cased++
+		case unicode.IsLower(r):
+			cased++
+		case unicode.Is(unicode.So, r):
+			signals.Emoji++
+		}
+
+		if unicode.IsSpace(r) {
+			last, run = -1, 0
+			continue
+		}
+		if unicode.ToLower(r) == last {
+			run++
+		} else {
+			last, run = unicode.ToLower(r), 1
+		}
+		if run > signals.Flood {
+			signals.Flood = run
+		}
+	}
+
+	if cased >= shoutingMinLetters {
+		signals.Shouting = float64(upper) / float64(cased)
+	}
+	return signals
+}
+
+// isEmojiModifier reports whether r only changes how the emoji before it looks: variation selectors, skin tones and
+// zero-width joiners
+func isEmojiModifier(r rune) bool {
+	return r == 0xFE0E || r == 0xFE0F || r == 0x200D || (r >= 0x1F3FB && r <= 0x1F3FF)
+}
@@ -0,0 +1,45 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestDetectSignals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Signals
+	}{
+		{"hello there", Signals{Shouting: 0, Flood: 2, Emoji: 0}},
+		{"WHY WOULD YOU", Signals{Shouting: 1, Flood: 1, Emoji: 0}},
+		{"Hello WORLD", Signals{Shouting: 0.6, Flood: 2, Emoji: 0}},
+		{"LOL", Signals{Shouting: 0, Flood: 1, Emoji: 0}},
+		{"nooOOOoo", Signals{Shouting: 0.375, Flood: 7, Emoji: 0}},
+		{"❤️❤️❤️❤️ 👍🏽", Signals{Shouting: 0, Flood: 4, Emoji: 5}},
+		{"!!!!!! ok", Signals{Shouting: 0, Flood: 6, Emoji: 0}},
+		{"", Signals{}},
+	}
+	for _, tt := range tests {
+		if signals := DetectSignals(tt.input); signals != tt.expected {
+			t.Errorf("got signals %+v for %q, want %+v", signals, tt.input, tt.expected)
+		}
+	}
+}
+
+func TestInspectSignals(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	if result, _ := filter.Inspect("WHAT THE HEEEEELL"); result.Signals != (Signals{}) {
+		t.Errorf("got signals %+v with detection disabled, want none", result.Signals)
+	}
+
+	filter.DetectSignals = true
+	result, err := filter.Inspect("WHAT THE HEEEEELL")
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	if result.Signals.Shouting != 1 || result.Signals.Flood != 5 {
+		t.Errorf("got signals %+v, want full shouting and a flood of 5", result.Signals)
+	}
+	if len(result.Words) != 0 {
+		t.Errorf("got words %v, want signals to never trip the filter", result.Words)
+	}
+}
@@ -0,0 +1,31 @@
+package swearfilter
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// CorpusHit is a line of a sample corpus that a candidate word would trip
+type CorpusHit struct {
+	Line int //Line number in the corpus, starting at 1
+	Text string
+}
+
+// WouldMatch reports every line of corpus that candidate would trip if it were added to the wordlist, using the
+// filter's current normalization settings, so the blast radius of a short or ambiguous word can be previewed
+func (filter *SwearFilter) WouldMatch(candidate string, corpus io.Reader) (hits []CorpusHit, err error) {
+	candidate = strings.ToLower(candidate)
+	scanner := bufio.NewScanner(corpus)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		caught, err := filter.catches(candidate, scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		if caught {
+			hits = append(hits, CorpusHit{Line: line, Text: scanner.Text()})
+		}
+	}
+	return hits, scanner.Err()
+}
@@ -0,0 +1,34 @@
+package swearfilter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWouldMatch(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	corpus := strings.Join([]string{
+		"what a classy move",
+		"nothing here",
+		"kick his @ss",
+		"",
+		"ASSESSMENT due friday",
+	}, "\n")
+
+	hits, err := filter.WouldMatch("ass", strings.NewReader(corpus))
+	if err != nil {
+		t.Fatalf("WouldMatch failed: %v", err)
+	}
+	expected := []int{1, 3, 5}
+	if len(hits) != len(expected) {
+		t.Fatalf("got hits %v, want lines %v", hits, expected)
+	}
+	for i, hit := range hits {
+		if hit.Line != expected[i] {
+			t.Errorf("got line %d, want %d", hit.Line, expected[i])
+		}
+	}
+	if words := filter.Words(); len(words) != 1 {
+		t.Errorf("got words %v after WouldMatch, want the wordlist untouched", words)
+	}
+}
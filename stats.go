@@ -0,0 +1,245 @@
+package swearfilter
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// MatchEvent describes a word found in a checked message, passed to the filter's hooks
+type MatchEvent struct {
+	Word        string //The word from the wordlist that was found
+	Message     string //The message as it was passed to the check, empty in privacy mode
+	MessageHash string //Salted hash of the message in privacy mode, so repeats can be correlated without the content
+	Shadow      bool   //Whether the word is monitor-only
+
+	Start, End int    //Byte range of the message the word was first found at, unset for co-occurrence rules
+	Category   string //Category of the word or pattern (see WordOptions.Category)
+}
+
+// Stats is a snapshot of the counters kept by a SwearFilter over a span of time
+type Stats struct {
+	Since time.Time `json:"since"` //Start of the span the counters cover
+	Until time.Time `json:"until"` //End of the span the counters cover
+
+	Shadow map[string]uint64      `json:"shadow"` //Number of checked messages each monitor-only word was found in
+	Canary map[string]CanaryStats `json:"canary"` //Would-have-blocked vs blocked counts for each word under a partial rollout
+
+	Sampled uint64 `json:"sampled"` //Checks that were inspected while SampleRate was in effect
+	Skipped uint64 `json:"skipped"` //Checks that were passed through uninspected while SampleRate was in effect
+
+	Checks  uint64            `json:"checks"`  //Checks that were inspected, whether or not SampleRate was in effect
+	Matches uint64            `json:"matches"` //Checks that tripped on at least one word
+	Hits    map[string]uint64 `json:"hits"`    //Number of checked messages each word, pattern or rule tripped
+}
+
+// DefaultStatsBucket is the granularity of retained stats when StatsRetention is set but StatsBucket isn't
+const DefaultStatsBucket = time.Hour
+
+type stats struct {
+	mutex   sync.Mutex
+	buckets []statsBucket //Oldest first
+}
+
+// statsBucket holds the counters for checks made from start until the next bucket starts
+type statsBucket struct {
+	start            time.Time
+	shadow           map[string]uint64
+	canary           map[string]CanaryStats
+	sampled, skipped uint64
+
+	checks, matches uint64
+	hits            map[string]uint64
+}
+
+// Stats returns the counters summed over the retention window, or since the filter was created or last reset if
+// StatsRetention isn't set
+func (filter *SwearFilter) Stats() Stats {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+	now := filter.now()
+	filter.stats.mutex.Lock()
+	defer filter.stats.mutex.Unlock()
+
+	filter.pruneStats(now)
+	total := newStats(now, now)
+	for i := range filter.stats.buckets {
+		bucket := &filter.stats.buckets[i]
+		if i == 0 {
+			total.Since = bucket.start
+		}
+		bucket.addTo(&total)
+	}
+	return total
+}
+
+// StatsBuckets returns the retained counters bucket by bucket, oldest first, for showing recent trends
+func (filter *SwearFilter) StatsBuckets() []Stats {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+	now := filter.now()
+	filter.stats.mutex.Lock()
+	defer filter.stats.mutex.Unlock()
+
+	filter.pruneStats(now)
+	snapshots := make([]Stats, len(filter.stats.buckets))
+	for i := range filter.stats.buckets {
+		bucket := &filter.stats.buckets[i]
+		until := now
+		if i+1 < len(filter.stats.buckets) {
+			until = filter.stats.buckets[i+1].start
+		}
+		snapshots[i] = newStats(bucket.start, until)
+		bucket.addTo(&snapshots[i])
+	}
+	return snapshots
+}
+
+// ExportStats writes the total and per-bucket counters to w as JSON
+func (filter *SwearFilter) ExportStats(w io.Writer) error {
+	return json.NewEncoder(w).Encode(struct {
+		Total   Stats   `json:"total"`
+		Buckets []Stats `json:"buckets"`
+	}{filter.Stats(), filter.StatsBuckets()})
+}
+
+// ResetStats discards every counter
+func (filter *SwearFilter) ResetStats() {
+	filter.stats.mutex.Lock()
+	defer filter.stats.mutex.Unlock()
+
+	filter.stats.buckets = nil
+}
+
+func newStats(since, until time.Time) Stats {
+	return Stats{
+		Since:  since,
+		Until:  until,
+		Shadow: make(map[string]uint64),
+		Canary: make(map[string]CanaryStats),
+		Hits:   make(map[string]uint64),
+	}
+}
+
+func (bucket *statsBucket) addTo(total *Stats) {
+	for word, hits := range bucket.shadow {
+		total.Shadow[word] += hits
+	}
+	for word, hits := range bucket.canary {
+		counts := total.Canary[word]
+		counts.WouldBlock += hits.WouldBlock
+		counts.Blocked += hits.Blocked
+		total.Canary[word] = counts
+	}
+	total.Sampled += bucket.sampled
+	total.Skipped += bucket.skipped
+	for word, hits := range bucket.hits {
+		total.Hits[word] += hits
+	}
+	total.Checks += bucket.checks
+	total.Matches += bucket.matches
+}
+
+// currentStats returns the bucket checks made at now are counted in, the filter's and the stats lock must be held
+func (filter *SwearFilter) currentStats(now time.Time) *statsBucket {
+	start := now
+	if filter.StatsRetention > 0 {
+		start = now.Truncate(filter.statsBucket())
+	}
+
+	buckets := filter.stats.buckets
+	if len(buckets) > 0 && (filter.StatsRetention <= 0 || !buckets[len(buckets)-1].start.Before(start)) {
+		return &buckets[len(buckets)-1]
+	}
+	filter.pruneStats(now)
+	filter.stats.buckets = append(filter.stats.buckets, statsBucket{
+		start:  start,
+		shadow: make(map[string]uint64),
+		canary: make(map[string]CanaryStats),
+		hits:   make(map[string]uint64),
+	})
+	return &filter.stats.buckets[len(filter.stats.buckets)-1]
+}
+
+// pruneStats drops the buckets that ended before the retention window, the filter's and the stats lock must be held
+func (filter *SwearFilter) pruneStats(now time.Time) {
+	if filter.StatsRetention <= 0 {
+		return
+	}
+	cutoff := now.Add(-filter.StatsRetention)
+	keep := 0
+	for keep < len(filter.stats.buckets) && !filter.stats.buckets[keep].start.Add(filter.statsBucket()).After(cutoff) {
+		keep++
+	}
+	filter.stats.buckets = append(filter.stats.buckets[:0], filter.stats.buckets[keep:]...)
+}
+
+func (filter *SwearFilter) statsBucket() time.Duration {
+	if filter.StatsBucket > 0 {
+		return filter.StatsBucket
+	}
+	return DefaultStatsBucket
+}
+
+// record counts the outcome of a scan and prepares the events for its hooks, the filter's lock must be held
+func (filter *SwearFilter) record(msg string, now time.Time, result *scanResult) {
+	filter.stats.mutex.Lock()
+	bucket := filter.currentStats(now)
+	bucket.checks++
+	if len(result.tripped) > 0 {
+		bucket.matches++
+	}
+	for _, word := range result.tripped {
+		bucket.hits[word]++
+	}
+	for _, word := range result.shadow {
+		bucket.shadow[word]++
+	}
+	for _, hit := range result.canary {
+		counts := bucket.canary[hit.word]
+		counts.WouldBlock++
+		if hit.blocked {
+			counts.Blocked++
+		}
+		bucket.canary[hit.word] = counts
+	}
+	filter.stats.mutex.Unlock()
+
+	if filter.OnShadowMatch != nil {
+		result.onShadowMatch = filter.OnShadowMatch
+		for _, word := range result.shadow {
+			result.shadowEvents = append(result.shadowEvents, filter.event(word, msg, true, result.spans))
+		}
+	}
+	if filter.OnMatch != nil {
+		result.onMatch = filter.OnMatch
+		for _, word := range result.tripped {
+			result.matchEvents = append(result.matchEvents, filter.event(word, msg, false, result.spans))
+		}
+	}
+	if filter.OnNearMiss != nil {
+		result.onNearMiss = filter.OnNearMiss
+		for i := range result.nearMisses {
+			event := &result.nearMisses[i]
+			if filter.PrivacyMode {
+				event.Token, event.MessageHash = "", filter.hashMessage(msg)
+			} else {
+				event.Message = msg
+			}
+		}
+	}
+}
+
+// fire calls the hooks with the events prepared by record, it must not be called with the filter's lock held
+func (result scanResult) fire() {
+	for _, event := range result.shadowEvents {
+		result.onShadowMatch(event)
+	}
+	for _, event := range result.matchEvents {
+		result.onMatch(event)
+	}
+	for _, event := range result.nearMisses {
+		result.onNearMiss(event)
+	}
+}
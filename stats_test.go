@@ -0,0 +1,137 @@
+package swearfilter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestShadow(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.AddWithOptions(WordOptions{Shadow: true}, "darn")
+
+	var events []MatchEvent
+	filter.OnShadowMatch = func(event MatchEvent) {
+		events = append(events, event)
+	}
+
+	trippers, err := filter.Check("fuck this darn thing")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(trippers) != 1 || trippers[0] != "fuck" {
+		t.Errorf("got trippers %v, want %v", trippers, []string{"fuck"})
+	}
+	if len(events) != 1 || events[0].Word != "darn" || !events[0].Shadow {
+		t.Errorf("got shadow events %v, want one for %s", events, "darn")
+	}
+
+	filter.Check("darn it")
+	if hits := filter.Stats().Shadow["darn"]; hits != 2 {
+		t.Errorf("got shadow hits %d, want %d", hits, 2)
+	}
+
+	filter.Add("darn")
+	if opts, ok := filter.Options("darn"); !ok || opts.Shadow {
+		t.Errorf("got options %+v (found: %t) after Add, want enforced word", opts, ok)
+	}
+	trippers, _ = filter.Check("darn it")
+	if len(trippers) != 1 || trippers[0] != "darn" {
+		t.Errorf("got trippers %v, want %v", trippers, []string{"darn"})
+	}
+}
+
+func TestOnMatch(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.AddWithOptions(WordOptions{Category: "religious"}, "hell")
+	filter.AddWithOptions(WordOptions{Shadow: true}, "darn")
+
+	var events []MatchEvent
+	filter.OnMatch = func(event MatchEvent) {
+		//Called outside the lock, so the filter can be used from the hook
+		filter.Words()
+		events = append(events, event)
+	}
+
+	filter.Check("what the fuck, darn")
+	filter.Check("fuck this hell")
+	filter.Check("nice and clean")
+	want := []MatchEvent{
+		{Word: "fuck", Message: "what the fuck, darn", Start: 9, End: 13},
+		{Word: "fuck", Message: "fuck this hell", Start: 0, End: 4},
+		{Word: "hell", Message: "fuck this hell", Start: 10, End: 14, Category: "religious"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got events %+v, want %+v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("got event %+v, want %+v", events[i], want[i])
+		}
+	}
+
+	stats := filter.Stats()
+	if stats.Checks != 3 || stats.Matches != 2 {
+		t.Errorf("got %d checks and %d matches, want 3 and 2", stats.Checks, stats.Matches)
+	}
+	if stats.Hits["fuck"] != 2 || stats.Hits["hell"] != 1 || stats.Hits["darn"] != 0 {
+		t.Errorf("got hits %v, want 2 for fuck, 1 for hell and none for the monitor-only darn", stats.Hits)
+	}
+
+	filter.ResetStats()
+	if stats := filter.Stats(); stats.Checks != 0 || len(stats.Hits) != 0 {
+		t.Errorf("got stats %+v after ResetStats, want none", stats)
+	}
+}
+
+func TestStatsRetention(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	filter := NewSwearFilter(false)
+	filter.AddWithOptions(WordOptions{Shadow: true}, "darn")
+	filter.Clock = func() time.Time { return now }
+	filter.StatsRetention = 3 * time.Hour
+
+	for hour := 0; hour < 5; hour++ {
+		for i := 0; i <= hour; i++ {
+			filter.Check("darn")
+		}
+		now = now.Add(time.Hour)
+	}
+	now = now.Add(-time.Hour) //Still in the last bucket, at 14:30
+
+	buckets := filter.StatsBuckets()
+	if len(buckets) != 4 {
+		t.Fatalf("got %d buckets, want %d overlapping the window", len(buckets), 4)
+	}
+	for i, bucket := range buckets {
+		if want := uint64(i + 2); bucket.Shadow["darn"] != want {
+			t.Errorf("got %d hits in bucket %d, want %d", bucket.Shadow["darn"], i, want)
+		}
+	}
+	if !buckets[0].Since.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)) || !buckets[0].Until.Equal(buckets[1].Since) {
+		t.Errorf("got first bucket %v to %v, want 11:00 to the next bucket", buckets[0].Since, buckets[0].Until)
+	}
+
+	total := filter.Stats()
+	if total.Shadow["darn"] != 2+3+4+5 || !total.Since.Equal(buckets[0].Since) {
+		t.Errorf("got total %d since %v, want %d since %v", total.Shadow["darn"], total.Since, 14, buckets[0].Since)
+	}
+
+	var exported bytes.Buffer
+	if err := filter.ExportStats(&exported); err != nil {
+		t.Fatalf("ExportStats failed: %v", err)
+	}
+	var decoded struct {
+		Total   Stats
+		Buckets []Stats
+	}
+	if err := json.Unmarshal(exported.Bytes(), &decoded); err != nil || decoded.Total.Shadow["darn"] != 14 || len(decoded.Buckets) != 4 {
+		t.Errorf("got export %s (%v), want the total and buckets", exported.String(), err)
+	}
+
+	filter.ResetStats()
+	if hits := filter.Stats().Shadow["darn"]; hits != 0 {
+		t.Errorf("got %d hits after reset, want none", hits)
+	}
+}
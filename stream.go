@@ -0,0 +1,121 @@
+package swearfilter
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// streamChunkSize is how much CheckReader reads at a time
+const streamChunkSize = 32 * 1024
+
+// CheckReader checks text read from r as it arrives, calling fn with every tripped word as soon as it is found, with
+// Start and End relative to the start of the stream; it returns the first error from r or fn, or nil at the end of
+// the stream
+//
+// The new text is checked after every read, except for a short tail held back until more text arrives or the stream
+// ends, so that words straddling two reads are still found with enough context around them for exceptions, the
+// allowlist and word boundaries; only a single word spread out over more than about eight times its length (ex:
+// hundreds of repeated letters) can be missed. Like IncrementalChecker, streams aren't
+// counted in the stats nor passed to the hooks. Co-occurrence rules are not reported, see CheckDetailed.
+func (filter *SwearFilter) CheckReader(r io.Reader, fn func(Match) error) error {
+	context := filter.streamContext()
+	var window []byte //The unchecked text, after up to context bytes that were already checked
+	base := 0         //Offset of window in the stream
+	checked := 0      //Bytes at the start of window that were already checked
+	chunk := make([]byte, streamChunkSize)
+
+	for {
+		n, err := r.Read(chunk)
+		window = append(window, chunk[:n]...)
+		atEOF := err == io.EOF
+		if err != nil && !atEOF {
+			return err
+		}
+
+		//Leave enough unchecked text at the end of the window for words it might be the start of
+		limit := len(window)
+		if !atEOF {
+			limit = runeStart(window, len(window)-context)
+		}
+		if limit > checked {
+			if err := filter.checkWindow(window, base, checked, limit, fn); err != nil {
+				return err
+			}
+			checked = limit
+		}
+		if atEOF {
+			return nil
+		}
+
+		//Keep the checked text a word in the unchecked text could need as context and drop the rest
+		if drop := runeStart(window, checked-context); drop > 0 {
+			window = append(window[:0], window[drop:]...)
+			base += drop
+			checked -= drop
+		}
+	}
+}
+
+// checkWindow checks window and reports the words found starting between from and to
+func (filter *SwearFilter) checkWindow(window []byte, base, from, to int, fn func(Match) error) error {
+	msg := string(window)
+	var mapped mappedText
+	result, err := filter.scan(msg, scanOptions{key: msg, mapped: &mapped})
+	if err != nil || len(result.tripped) == 0 {
+		return err
+	}
+
+	filter.mutex.RLock()
+	matches, err := filter.locate(msg, mapped, result.tripped)
+	filter.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if match.Start < from || match.Start >= to {
+			continue
+		}
+		match.Start += base
+		match.End += base
+		if err := fn(match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamContext returns how much text around a word CheckReader needs to be sure to find it: enough for the
+// longest word, exception or allowed word, spaced out or written in leet speak
+func (filter *SwearFilter) streamContext() int {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	longest := 0
+	for word := range filter.BadWords {
+		if len(word) > longest {
+			longest = len(word)
+		}
+		for _, exception := range filter.entries[word].Exceptions {
+			if len(exception) > longest {
+				longest = len(exception)
+			}
+		}
+	}
+	for word := range filter.allowed {
+		if len(word) > longest {
+			longest = len(word)
+		}
+	}
+	return 8*longest + 64
+}
+
+// runeStart returns i moved back to the start of the rune it falls in, or 0 if it is negative
+func runeStart(b []byte, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	for i > 0 && i < len(b) && !utf8.RuneStart(b[i]) {
+		i--
+	}
+	return i
+}
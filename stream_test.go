@@ -0,0 +1,100 @@
+package swearfilter
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestCheckReader(t *testing.T) {
+	filter := NewSwearFilter(true, "fuck", "shit")
+	filter.AddWithOptions(WordOptions{Exceptions: []string{"shitake"}}, "shit")
+
+	long := strings.Repeat("clean text ", streamChunkSize/10)
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"short", "what the fuck and sh1t"},
+		{"straddling", long + "fuck" + long + "s h i t" + long},
+		{"exception straddling", long + "shitake" + long},
+		{"multibyte", strings.Repeat("é", streamChunkSize) + "Fück"},
+		{"clean", long},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := filter.CheckDetailed(tt.input)
+			if err != nil {
+				t.Fatalf("CheckDetailed failed: %v", err)
+			}
+
+			var matches []Match
+			err = filter.CheckReader(iotest.HalfReader(strings.NewReader(tt.input)), func(match Match) error {
+				matches = append(matches, match)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("CheckReader failed: %v", err)
+			}
+			if !reflect.DeepEqual(matches, expected) {
+				t.Errorf("got %+v, want %+v", matches, expected)
+			}
+		})
+	}
+}
+
+func TestCheckReaderErrors(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+
+	stop := errors.New("stop")
+	calls := 0
+	err := filter.CheckReader(strings.NewReader("fuck fuck fuck"), func(Match) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("got %v after %d calls, want %v after 1", err, calls, stop)
+	}
+
+	broken := errors.New("broken")
+	err = filter.CheckReader(io.MultiReader(strings.NewReader("fuck"), iotest.ErrReader(broken)), func(Match) error {
+		return nil
+	})
+	if err != broken {
+		t.Errorf("got %v, want %v", err, broken)
+	}
+}
+
+func TestCheckReaderEarly(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+
+	r, w := io.Pipe()
+	found := make(chan Match, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- filter.CheckReader(r, func(match Match) error {
+			found <- match
+			return nil
+		})
+	}()
+
+	//The stream stays open, the word must be reported once enough text follows it
+	go w.Write([]byte("what the fuck" + strings.Repeat(" and then some", 10)))
+	select {
+	case match := <-found:
+		if match.Word != "fuck" || match.Start != 9 || match.End != 13 {
+			t.Errorf("got %+v, want fuck at 9", match)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("got nothing while the stream was open, want the word reported")
+	}
+
+	w.Close()
+	if err := <-done; err != nil {
+		t.Errorf("CheckReader failed: %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package swearfilter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// PathMatch is a value inside a structured document that tripped the filter
+type PathMatch struct {
+	Path  string   //Location of the value in the document, such as $.reviews[0].text
+	Words []string //Words the value tripped
+}
+
+var regexJSONIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// CheckJSON checks every string value in a JSON document and reports the path of each value that tripped the filter
+//
+// Paths use JSONPath-style notation and are reported in document order, with object keys visited in sorted order.
+func (filter *SwearFilter) CheckJSON(data []byte) (matches []PathMatch, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err = decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	err = filter.walkJSON("$", document, &matches)
+	return matches, err
+}
+
+func (filter *SwearFilter) walkJSON(path string, value interface{}, matches *[]PathMatch) error {
+	switch value := value.(type) {
+	case string:
+		return filter.checkPath(path, value, matches)
+	case []interface{}:
+		for i, element := range value {
+			if err := filter.walkJSON(path+"["+strconv.Itoa(i)+"]", element, matches); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := path + "[" + strconv.Quote(key) + "]"
+			if regexJSONIdentifier.MatchString(key) {
+				child = path + "." + key
+			}
+			if err := filter.walkJSON(child, value[key], matches); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkPath checks a single value found at path and records it if it tripped the filter
+func (filter *SwearFilter) checkPath(path, value string, matches *[]PathMatch) error {
+	trippedWords, err := filter.Check(value)
+	if err != nil {
+		return err
+	}
+	if len(trippedWords) > 0 {
+		sort.Strings(trippedWords)
+		*matches = append(*matches, PathMatch{Path: path, Words: trippedWords})
+	}
+	return nil
+}
+
+// CheckStruct checks the exported string fields of v, following pointers, nested structs, slices, arrays and maps,
+// and reports the path of each field that tripped the filter (ex: Profile.Bio, Reviews[1].Text, Meta["motto"])
+//
+// Fields tagged `swearfilter:"skip"` are never visited. If a struct has any field tagged `swearfilter:"check"`,
+// only its tagged fields are visited, which lets large forms opt in to the few fields users control. Each pointer, map
+// and slice is only walked once, so values referencing themselves are safe to check.
+func (filter *SwearFilter) CheckStruct(v interface{}) (matches []PathMatch, err error) {
+	err = filter.walkValue("", reflect.ValueOf(v), make(map[visit]struct{}), &matches)
+	return matches, err
+}
+
+// visit is a pointer, map or slice CheckStruct has already walked, so that values referencing themselves are only
+// walked once
+type visit struct {
+	pointer uintptr
+	length  int
+	typ     reflect.Type
+}
+
+// seen reports whether the pointer, map or slice value was already walked, and marks it walked
+func seen(value reflect.Value, visited map[visit]struct{}) bool {
+	key := visit{pointer: value.Pointer(), typ: value.Type()}
+	if value.Kind() == reflect.Slice {
+		key.length = value.Len()
+	}
+	if _, ok := visited[key]; ok {
+		return true
+	}
+	visited[key] = struct{}{}
+	return false
+}
+
+func (filter *SwearFilter) walkValue(path string, value reflect.Value, visited map[visit]struct{}, matches *[]PathMatch) error {
+	switch value.Kind() {
+	case reflect.String:
+		return filter.checkPath(path, value.String(), matches)
+	case reflect.Ptr:
+		if value.IsNil() || seen(value, visited) {
+			return nil
+		}
+		return filter.walkValue(path, value.Elem(), visited, matches)
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return filter.walkValue(path, value.Elem(), visited, matches)
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return nil //Raw bytes are not text
+		}
+		if value.Kind() == reflect.Slice && (value.Len() == 0 || seen(value, visited)) {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := filter.walkValue(path+"["+strconv.Itoa(i)+"]", value.Index(i), visited, matches); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.IsNil() || seen(value, visited) {
+			return nil
+		}
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			child := path + "[" + fmt.Sprint(key.Interface()) + "]"
+			if key.Kind() == reflect.String {
+				child = path + "[" + strconv.Quote(key.String()) + "]"
+			}
+			if err := filter.walkValue(child, value.MapIndex(key), visited, matches); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		structType := value.Type()
+		optIn := false
+		for i := 0; i < structType.NumField(); i++ {
+			if structType.Field(i).Tag.Get("swearfilter") == "check" {
+				optIn = true
+				break
+			}
+		}
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+			tag := field.Tag.Get("swearfilter")
+			if field.PkgPath != "" || tag == "skip" || tag == "-" || (optIn && tag != "check") {
+				continue
+			}
+			child := field.Name
+			if path != "" {
+				child = path + "." + field.Name
+			}
+			if err := filter.walkValue(child, value.Field(i), visited, matches); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestCheckJSON(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "shit")
+	matches, err := filter.CheckJSON([]byte(`{
+		"user": {"name": "bob", "bio": "fuck off"},
+		"reviews": [{"text": "great"}, {"text": "shit product", "stars": 1}],
+		"odd key": "sh1t",
+		"tags": ["ok", null, true, 4.5]
+	}`))
+	if err != nil {
+		t.Fatalf("CheckJSON failed: %v", err)
+	}
+
+	expected := []string{`$["odd key"]`, "$.reviews[1].text", "$.user.bio"}
+	if len(matches) != len(expected) {
+		t.Fatalf("got matches %v, want paths %v", matches, expected)
+	}
+	for i, match := range matches {
+		if match.Path != expected[i] {
+			t.Errorf("got path %s, want %s", match.Path, expected[i])
+		}
+		if len(match.Words) != 1 {
+			t.Errorf("got words %v at %s, want one", match.Words, match.Path)
+		}
+	}
+
+	if _, err := filter.CheckJSON([]byte(`{"broken": `)); err == nil {
+		t.Errorf("got no error for invalid JSON, want one")
+	}
+}
+
+func TestCheckStruct(t *testing.T) {
+	type review struct {
+		Text  string
+		Stars int
+	}
+	type profile struct {
+		Bio    string
+		Secret string `swearfilter:"skip"`
+		motto  string
+	}
+	type form struct {
+		Name    string
+		Profile *profile
+		Reviews []review
+		Meta    map[string]string
+		Extra   interface{}
+		Raw     []byte
+	}
+	type optIn struct {
+		Title string `swearfilter:"check"`
+		Body  string
+	}
+
+	filter := NewSwearFilter(false, "fuck", "shit")
+	matches, err := filter.CheckStruct(&form{
+		Name:    "bob",
+		Profile: &profile{Bio: "fuck off", Secret: "shit", motto: "shit"},
+		Reviews: []review{{Text: "fine"}, {Text: "shit"}},
+		Meta:    map[string]string{"motto": "fuck it", "color": "blue"},
+		Extra:   optIn{Title: "shit", Body: "fuck"},
+		Raw:     []byte("fuck"),
+	})
+	if err != nil {
+		t.Fatalf("CheckStruct failed: %v", err)
+	}
+
+	expected := []string{"Profile.Bio", "Reviews[1].Text", `Meta["motto"]`, "Extra.Title"}
+	if len(matches) != len(expected) {
+		t.Fatalf("got matches %v, want paths %v", matches, expected)
+	}
+	for i, match := range matches {
+		if match.Path != expected[i] {
+			t.Errorf("got path %s, want %s", match.Path, expected[i])
+		}
+	}
+}
+
+func TestCheckStructCycles(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+
+	object := map[string]interface{}{"text": "fuck"}
+	object["self"] = object
+	list := []interface{}{"fuck", nil}
+	list[1] = list
+	type node struct {
+		Text string
+		Next *node
+	}
+	ring := &node{Text: "fuck"}
+	ring.Next = &node{Text: "fine", Next: ring}
+
+	for name, v := range map[string]interface{}{"map": object, "slice": list, "pointer": ring} {
+		matches, err := filter.CheckStruct(v)
+		if err != nil {
+			t.Fatalf("%s: CheckStruct failed: %v", name, err)
+		}
+		if len(matches) != 1 {
+			t.Errorf("%s: got matches %v, want the word found once", name, matches)
+		}
+	}
+}
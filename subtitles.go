@@ -0,0 +1,123 @@
+package swearfilter
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+// CueMatch is a subtitle cue whose text tripped the filter
+type CueMatch struct {
+	Cue    int      //Position of the cue in the file, starting at 1
+	Timing string   //The cue's timing line (ex: 00:00:01,000 --> 00:00:04,000)
+	Text   string   //The cue's text with formatting tags removed
+	Words  []string //Words the cue's text tripped
+}
+
+var regexSubtitleTag = regexp.MustCompile(`<[^>]*>|\{\\[^}]*\}`)
+
+// CheckSubtitles parses an SRT or WebVTT file and checks the text of every cue, ignoring indices, timings,
+// headers, notes and formatting tags
+func (filter *SwearFilter) CheckSubtitles(r io.Reader) (matches []CueMatch, err error) {
+	return filter.filterSubtitles(r, nil)
+}
+
+// CensorSubtitles copies an SRT or WebVTT file from r to w, replacing tripped words in cue text the way Censor does
+// while leaving indices, timings, headers, notes, formatting tags and line endings untouched
+//
+// Each cue's text is checked once as a whole. A word interrupted by a formatting tag is replaced a part at a time.
+func (filter *SwearFilter) CensorSubtitles(r io.Reader, w io.Writer) (matches []CueMatch, err error) {
+	return filter.filterSubtitles(r, w)
+}
+
+func (filter *SwearFilter) filterSubtitles(r io.Reader, w io.Writer) (matches []CueMatch, err error) {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := bytes.SplitAfter(raw, []byte("\n"))
+
+	cue := 0
+	for start := 0; start < len(lines); {
+		//Subtitle blocks are separated by blank lines
+		end := start
+		for end < len(lines) && strings.TrimSpace(string(lines[end])) != "" {
+			end++
+		}
+		if end == start {
+			end++
+		}
+		block := lines[start:end]
+		start = end
+
+		timing := -1
+		for i, line := range block {
+			if strings.Contains(string(line), "-->") {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			continue //Headers, notes and styles have no timing line
+		}
+		cue++
+
+		text := make([]string, 0, len(block)-timing-1)
+		for _, line := range block[timing+1:] {
+			text = append(text, regexSubtitleTag.ReplaceAllString(strings.TrimRight(string(line), "\r\n"), ""))
+		}
+		msg := strings.Join(text, "\n")
+		var mapped mappedText
+		trippedWords, err := filter.check(msg, scanOptions{key: msg, mapped: &mapped})
+		if err != nil {
+			return nil, err
+		}
+		if len(trippedWords) == 0 {
+			continue
+		}
+		matches = append(matches, CueMatch{
+			Cue:    cue,
+			Timing: strings.TrimSpace(string(block[timing])),
+			Text:   msg,
+			Words:  trippedWords,
+		})
+
+		if w != nil {
+			filter.mutex.RLock()
+			spans := filter.wordSpans(msg, mapped, trippedWords, scanOptions{})
+			replace := filter.replacement()
+			filter.mutex.RUnlock()
+			censorCue(block[timing+1:], spans, replace)
+		}
+	}
+
+	if w != nil {
+		if _, err = w.Write(bytes.Join(lines, nil)); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// censorCue replaces the parts of a cue's text lines covered by the spans of their joined, untagged text, leaving
+// formatting tags and line endings untouched
+func censorCue(lines [][]byte, spans [][2]int, replace func(word string) string) {
+	offset := 0 //Offset of the line in the cue's text
+	for i, line := range lines {
+		body := bytes.TrimRight(line, "\r\n")
+		var censored bytes.Buffer
+		text := 0
+		tags := append(regexSubtitleTag.FindAllIndex(body, -1), []int{len(body), len(body)})
+		for _, tag := range tags {
+			censored.WriteString(censorSpans(string(body[text:tag[0]]), offset, spans, replace))
+			censored.Write(body[tag[0]:tag[1]])
+			offset += tag[0] - text
+			text = tag[1]
+		}
+		censored.Write(line[len(body):])
+		lines[i] = censored.Bytes()
+		offset++
+	}
+}
@@ -0,0 +1,111 @@
+package swearfilter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCensorSubtitles(t *testing.T) {
+	filter := NewSwearFilter(true, "fuck", "shit")
+
+	srt := "1\r\n00:00:01,000 --> 00:00:02,000\r\n<i>What the fûck?</i>\r\n\r\n" +
+		"2\r\n00:00:03,000 --> 00:00:04,000\r\nAll clear.\r\n\r\n" +
+		"3\r\n00:00:05,000 --> 00:00:06,000\r\n{\\an8}s h i t\r\nhappens\r\n"
+	var censored bytes.Buffer
+	matches, err := filter.CensorSubtitles(strings.NewReader(srt), &censored)
+	if err != nil {
+		t.Fatalf("CensorSubtitles failed: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Cue != 1 || matches[1].Cue != 3 {
+		t.Fatalf("got matches %v, want cues 1 and 3", matches)
+	}
+	if matches[0].Timing != "00:00:01,000 --> 00:00:02,000" || matches[0].Text != "What the fûck?" {
+		t.Errorf("got match %+v, want the timing line and untagged text", matches[0])
+	}
+
+	expected := "1\r\n00:00:01,000 --> 00:00:02,000\r\n<i>What the ****?</i>\r\n\r\n" +
+		"2\r\n00:00:03,000 --> 00:00:04,000\r\nAll clear.\r\n\r\n" +
+		"3\r\n00:00:05,000 --> 00:00:06,000\r\n{\\an8}* * * *\r\nhappens\r\n"
+	if censored.String() != expected {
+		t.Errorf("got censored output %q, want %q", censored.String(), expected)
+	}
+}
+
+func TestCensorSubtitlesOnce(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck", "shit")
+	var events []MatchEvent
+	filter.OnMatch = func(event MatchEvent) {
+		events = append(events, event)
+	}
+	filter.AddRule(CoOccurrence{A: "kill", B: "you"})
+
+	srt := "1\n00:00:01,000 --> 00:00:02,000\nfuck this <b>shit</b>,\nand fuck it\n\n" +
+		"2\n00:00:03,000 --> 00:00:04,000\n<i>I'll</i> kill\nyou\n"
+	var censored bytes.Buffer
+	matches, err := filter.CensorSubtitles(strings.NewReader(srt), &censored)
+	if err != nil {
+		t.Fatalf("CensorSubtitles failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("got matches %v, want both cues", matches)
+	}
+	if checks := filter.Stats().Checks; checks != 2 {
+		t.Errorf("got %d checks, want one per cue", checks)
+	}
+	words := make(map[string]int)
+	for _, event := range events {
+		words[event.Word]++
+	}
+	if words["fuck"] != 1 || words["shit"] != 1 {
+		t.Errorf("got match events %+v, want one per tripped word of each cue", events)
+	}
+
+	expected := "1\n00:00:01,000 --> 00:00:02,000\n**** this <b>****</b>,\nand **** it\n\n" +
+		"2\n00:00:03,000 --> 00:00:04,000\n<i>I'll</i> kill\nyou\n"
+	if censored.String() != expected {
+		t.Errorf("got censored output %q, want %q", censored.String(), expected)
+	}
+}
+
+func TestCheckSubtitles(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	vtt := "WEBVTT\n\nNOTE fuck this note\n\nintro\n00:01.000 --> 00:04.000 align:start\n<c.red>fuck</c> off\n"
+	matches, err := filter.CheckSubtitles(strings.NewReader(vtt))
+	if err != nil {
+		t.Fatalf("CheckSubtitles failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Cue != 1 || matches[0].Text != "fuck off" {
+		t.Errorf("got matches %v, want only the cue text checked", matches)
+	}
+}
+
+func TestCensorSubtitlesLikeCensor(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.CensorRune = '#'
+
+	srt := "1\r\n00:00:01,000 --> 00:00:02,000\r\nhellofuck <i>f</i>uck\r\nyou\r\n"
+	var censored bytes.Buffer
+	if _, err := filter.CensorSubtitles(strings.NewReader(srt), &censored); err != nil {
+		t.Fatalf("CensorSubtitles failed: %v", err)
+	}
+	expected := "1\r\n00:00:01,000 --> 00:00:02,000\r\nhello#### <i>#</i>###\r\nyou\r\n"
+	if censored.String() != expected {
+		t.Errorf("got censored output %q, want %q", censored.String(), expected)
+	}
+	if text, _, _ := filter.Censor("hellofuck fuck\nyou"); text != "hello#### ####\nyou" {
+		t.Errorf("got %q from Censor, want the same replacements", text)
+	}
+
+	filter.CensorFunc = func(word string) string {
+		return "[" + word + "]"
+	}
+	censored.Reset()
+	if _, err := filter.CensorSubtitles(strings.NewReader(srt), &censored); err != nil {
+		t.Fatalf("CensorSubtitles failed: %v", err)
+	}
+	expected = "1\r\n00:00:01,000 --> 00:00:02,000\r\nhello[fuck] <i>[f]</i>[uck]\r\nyou\r\n"
+	if censored.String() != expected {
+		t.Errorf("got censored output %q with CensorFunc, want %q", censored.String(), expected)
+	}
+}
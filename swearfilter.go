@@ -1,12 +1,12 @@
 package swearfilter
 
 import (
-	"golang.org/x/text/transform"
-	"golang.org/x/text/unicode/norm"
-	"regexp"
+	"context"
+	"golang.org/x/text/secure/precis"
+	"reflect"
 	"strings"
 	"sync"
-	"unicode"
+	"time"
 )
 
 var multiCharLeet = map[string]string{
@@ -60,17 +60,104 @@ type SwearFilter struct {
 	DisableZeroWidthStripping       bool //Disables stripping zero-width spaces
 	EnableSpacedBypass              bool //Disables testing for spaced bypasses (if hell is in filter, look for occurrences of h and detect only alphabetic characters that follow; ex: h[space]e[space]l[space]l[space] -> hell)
 	DisableLeetSpeak                bool
-
-	//A list of words to check against the filters
+	DisableConfusableFolding        bool    //Disables folding lookalike characters from other scripts and fullwidth forms to ASCII (ex: fսсk -> fuck)
+	DisableRepeatCollapse           bool    //Disables collapsing runs of 3 or more of the same character before matching (ex: fuuuuck -> fuck)
+	DisablePunycodeDecoding         bool    //Disables decoding punycode labels before matching (ex: xn--fck-hoa -> fück -> fuck)
+	DetectPII                       bool    //Enables detecting emails, phone numbers and card numbers, which trip the message under the name of their PIIKind (see FindPII)
+	DetectSignals                   bool    //Enables measuring shouting and character flooding in Inspect (see DetectSignals)
+	RequireWordBoundaries           bool    //Only matches words on their own, not inside longer words (ex: hell in hello or shell)
+	MaxEditDistance                 int     //When above 0, also matches words misspelled by up to that many edits (ex: fcuk), for words of 4 or more letters; words of 4 or 5 letters are only matched with two adjacent letters swapped
+	DisableTranspositions           bool    //Counts two swapped adjacent runes as two edits in fuzzy matching instead of one, as plain Levenshtein distance does, so words of 4 or 5 letters are never matched fuzzily
+	BlockShorteners                 bool    //Reports links through known URL shorteners in Inspect, as their destination can't be checked
+	SampleRate                      float64 //When between 0 and 1, only that fraction of checks is inspected, chosen by message hash; the rest trip nothing
+	AsyncWorkers                    int     //Maximum number of CheckAsync calls run at once, defaults to the number of CPUs
+
+	//Normalization stages messages go through before matching, when set the Disable options above are ignored and
+	//only these run, in order (see DefaultNormalizers)
+	Normalizers []Normalizer
+	LeetSpeak   LeetSpeakNormalizer //Leet speak translation the default pipeline runs, set its maps to extend it
+
+	//Keyboard layout on which, in fuzzy matching of words of 6 or more letters, substituting a rune with one on a
+	//neighbouring key counts as half an edit (ex: bsdtard -> bastard), as typos often do; none if nil
+	Keyboard KeyboardLayout
+
+	//Algorithm by which words of 4 or more letters are also found in the words of a message that sound like them (ex:
+	//phuk -> fuck), none if unset; such matches are uncertain, as plenty of innocent words sound alike (ex: fake, beach),
+	//so they are only reported by CheckDetailed and Inspect as MatchPhonetic unless PhoneticBlocking is set
+	Phonetic         PhoneticAlgorithm
+	PhoneticBlocking bool //Makes phonetic matches trip the message and be censored like any other
+
+	//Languages whose words (see WordOptions.Language) are matched, along with the words without one; every language is
+	//matched if empty (see CheckLanguages)
+	ActiveLanguages []string
+
+	Weights         ScoreWeights     //Weights Score gives words by severity and category
+	Clock           func() time.Time //Returns the time used to evaluate word schedules and bucket stats, defaults to time.Now
+	StatsRetention  time.Duration    //When set, stats only cover this much recent time instead of growing forever
+	StatsBucket     time.Duration    //Granularity that retained stats are kept and expire in, defaults to DefaultStatsBucket
+	UsernameProfile *precis.Profile  //Profile CheckUsername prepares names with before checking (ex: precis.UsernameCaseMapped), none if nil
+
+	//Conflict resolution between entries found in the same message
+	CategoryActions map[string]Action //Action taken by entries of each category that don't set one themselves
+	Precedence      []RuleKind        //Order in which the kinds of matching rules win, defaults to DefaultPrecedence
+
+	//Privacy settings for deployments where message content must not leave the filter
+	PrivacyMode bool   //Never passes message content to hooks, only a salted hash of it (see MatchEvent.MessageHash)
+	PrivacySalt []byte //Salt for message hashes in privacy mode, a random one is generated per filter if empty
+	saltOnce    sync.Once
+	randomSalt  []byte
+
+	//Replacement Censor masks tripped words with
+	CensorRune rune                     //Rune every character of a tripped word is replaced with, defaults to *
+	CensorFunc func(word string) string //When set, replaces each tripped word, as it appears in the message, with what it returns instead
+
+	//Hooks called after a check has finished, outside of the filter's lock
+	OnShadowMatch func(MatchEvent)    //Called for every monitor-only word found in a checked message
+	OnMatch       func(MatchEvent)    //Called for every word, pattern or rule that tripped a checked message
+	OnNearMiss    func(NearMissEvent) //Called for every word of a checked message one edit too far from a fuzzy or phonetic word to match it
+
+	//A list of words to check against the filters, call Compile after changing it directly
 	BadWords map[string]struct{}
+	entries  map[string]WordOptions
+	patterns map[string]pattern
+	domains  map[string]struct{}
+	rules    map[string]CoOccurrence
+	allowed  map[string]struct{}
 	mutex    sync.RWMutex
+
+	matcher      *compiledWords //Built over BadWords on the first check after it changed, nil until then
+	matcherMutex sync.Mutex
+
+	stats stats
+	async asyncPool
+
+	defaultSpacedBypass bool //EnableSpacedBypass as passed to NewSwearFilter, restored by Reset
+}
+
+// WordOptions contains per-word settings for an entry in the wordlist
+type WordOptions struct {
+	Action         Action   //What the word does to a message it's found in, see Precedence for when several words disagree
+	Shadow         bool     //Marks the word as monitor-only: it is counted and passed to OnShadowMatch, but never tripped
+	RolloutPercent int      //When between 1 and 99, only enforces the word for that percentage of checks (see CheckFor)
+	Schedule       Schedule //When set, the word is only matched while the schedule is active
+
+	Severity Severity //How offensive the word is, used to weigh it in Score
+	Category string   //Kind of word (ex: profanity, slur, harassment), used to weigh it in Score
+	Weight   float64  //When set, overrides the weight the word's severity and category would give it in Score
+	Tags     []string //Free-form labels for managing words in bulk (ex: the pack a word was imported from)
+	Language string   //Language the word belongs to, as an ISO 639-1 code (ex: "en"), see ActiveLanguages
+
+	Exceptions            []string //Longer words containing the entry it must not match inside (ex: "assign" for "ass"), compared against the normalized message
+	RequireWordBoundaries bool     //Only matches the word on its own, not inside longer words, even if the filter doesn't require it
+	MaxEditDistance       int      //When set, overrides the filter's MaxEditDistance for the word, -1 disabling fuzzy matching for it
 }
 
 // NewSwearFilter returns an initialized SwearFilter struct to check messages against
 func NewSwearFilter(enableSpacedBypass bool, uhohwords ...string) (filter *SwearFilter) {
 	filter = &SwearFilter{
-		EnableSpacedBypass: enableSpacedBypass,
-		BadWords:           make(map[string]struct{}),
+		EnableSpacedBypass:  enableSpacedBypass,
+		BadWords:            make(map[string]struct{}),
+		defaultSpacedBypass: enableSpacedBypass,
 	}
 	for _, word := range uhohwords {
 		filter.BadWords[word] = struct{}{}
@@ -80,122 +167,391 @@ func NewSwearFilter(enableSpacedBypass bool, uhohwords ...string) (filter *Swear
 
 // Check will return any words that trip an enabled swear filter, an error if any, or nothing if you've removed all the words for some reason
 func (filter *SwearFilter) Check(msg string) (trippedWords []string, err error) {
-	filter.mutex.RLock()
-	defer filter.mutex.RUnlock()
+	return filter.check(msg, scanOptions{key: msg})
+}
 
-	if filter.BadWords == nil || len(filter.BadWords) == 0 {
-		return nil, nil
+// check scans msg, recording the outcome in the stats, then fires the hooks once the filter's lock is released
+func (filter *SwearFilter) check(msg string, opts scanOptions) (trippedWords []string, err error) {
+	opts.record = true
+	result, err := filter.scan(msg, opts)
+	if err != nil {
+		return nil, err
 	}
+	result.fire()
+	return result.tripped, nil
+}
+
+// scanOptions adjusts a single scan without touching the filter's settings
+type scanOptions struct {
+	key                 string //Key that words with a RolloutPercent are bucketed by
+	disableSpacedBypass bool   //Skips the spaced bypass check even if the filter enables it
+	disableLeetSpeak    bool   //Skips leet speak normalization even if the filter enables it
+	record              bool   //Samples the check, counts it in the stats and prepares the hook events
+
+	timings  *[]StageTiming //Collects how long each stage took when set
+	fuzzy    *StageTiming   //Collects how long fuzzy and phonetic matching took, set by scan when collecting timings
+	decision *Decision      //Receives the rule that decided the outcome when set
+	mapped   *mappedText    //Receives the normalized message and where it came from in msg when set
+
+	languages map[string]bool //Lowercase languages whose words are matched instead of the filter's ActiveLanguages when set
+
+	ctx         context.Context //Cancels the scan when done, never if nil
+	normalizers []Normalizer    //Pipeline run instead of the filter's when set
+	minSeverity Severity        //Severity below which words don't trip the message, none if unset
+	maxMatches  int             //Number of tripped words the scan stops at, no limit if 0
+}
+
+// scanResult holds the outcome of matching a message against the wordlist
+type scanResult struct {
+	tripped []string    //Enforced words that were found
+	shadow  []string    //Monitor-only words that were found
+	canary  []canaryHit //Words under a partial rollout that were found, whether enforced or not
 
-	message := strings.ToLower(msg)
+	spans map[string]Span //Where in the message each found word was first found, only for the hooks
 
-	if !filter.DisableLeetSpeak {
-		message = filter.normalizeLeetSpeak(message)
+	onShadowMatch func(MatchEvent) //The hook to fire with events once the lock is released
+	shadowEvents  []MatchEvent
+	onMatch       func(MatchEvent)
+	matchEvents   []MatchEvent
+	onNearMiss    func(NearMissEvent)
+	nearMisses    []NearMissEvent
+}
+
+// scan matches msg against the wordlist, separating enforced words from monitor-only ones
+func (filter *SwearFilter) scan(msg string, opts scanOptions) (result scanResult, err error) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	now := filter.now()
+	if opts.record && !filter.sampled(opts.key, now) {
+		return scanResult{tripped: make([]string, 0)}, nil
 	}
-	//Normalize the text
-	if !filter.DisableNormalize {
-		bytes := make([]byte, len(message))
-		normalize := transform.Chain(norm.NFD, transform.RemoveFunc(func(r rune) bool {
-			return unicode.Is(unicode.Mn, r)
-		}), norm.NFC)
-		_, _, err = normalize.Transform(bytes, []byte(message), true)
-		if err != nil {
-			return nil, err
+	if len(filter.BadWords) == 0 && len(filter.rules) == 0 && len(filter.patterns) == 0 {
+		if opts.record {
+			filter.record(msg, now, &result)
 		}
-		message = string(bytes)
+		return result, nil
+	}
+
+	mapped, err := filter.normalizeMapped(msg, opts)
+	if err != nil {
+		return scanResult{}, err
 	}
-	//Turn tabs into spaces
-	if !filter.DisableSpacedTab {
-		message = strings.Replace(message, "\t", " ", -1)
+	if opts.mapped != nil {
+		*opts.mapped = mapped
+	} else {
+		defer mapped.release()
 	}
+	message := mapped.text
 
-	//Get rid of zero-width spaces
-	if !filter.DisableZeroWidthStripping {
-		message = strings.Replace(message, "\u200b", "", -1)
+	if opts.timings != nil {
+		opts.fuzzy = &StageTiming{}
 	}
+	start := opts.startTiming()
+	defer opts.lapMatch(&start)
 
-	//Convert multiple re-occurring whitespaces into a single space
-	if !filter.DisableMultiWhitespaceStripping {
-		regexLeadCloseWhitepace := regexp.MustCompile(`^[\s\p{Zs}]+|[\s\p{Zs}]+$`)
-		message = regexLeadCloseWhitepace.ReplaceAllString(message, "")
-		regexInsideWhitespace := regexp.MustCompile(`[\s\p{Zs}]{2,}`)
-		message = regexInsideWhitespace.ReplaceAllString(message, "")
+	words := filter.candidates(message, opts)
+	if _, ok := filter.BadWords[" "]; ok && message == "" {
+		words = append(words, " ")
 	}
 
-	trippedWords = make([]string, 0)
-	checkSpace := false
-	for swear := range filter.BadWords {
-		if swear == " " {
-			checkSpace = true
+	var candidates []candidate
+	blocked := 0
+	limited := func() bool {
+		return opts.maxMatches > 0 && blocked >= opts.maxMatches
+	}
+	found := func(swear string, entry WordOptions) {
+		action, kind := filter.action(entry)
+		switch action {
+		case ActionShadow:
+			result.shadow = append(result.shadow, swear)
+		case ActionBlock:
+			if entry.RolloutPercent > 0 && entry.RolloutPercent < 100 {
+				enforced := inRollout(swear, opts.key, entry.RolloutPercent)
+				result.canary = append(result.canary, canaryHit{word: swear, blocked: enforced})
+				if !enforced {
+					return
+				}
+			}
+		}
+		if action == ActionBlock {
+			blocked++
+		}
+		candidates = append(candidates, candidate{rule: swear, action: action, kind: kind})
+	}
+	for _, swear := range words {
+		if err := opts.err(); err != nil {
+			return scanResult{}, err
+		}
+		if limited() {
+			break
+		}
+		entry := filter.entries[swear]
+		if !filter.enabled(entry, opts, now) {
 			continue
 		}
-
-		if strings.Contains(message, swear) {
-			trippedWords = append(trippedWords, swear)
+		if swear != " " && !filter.matches(message, swear, filter.matchRule(swear).mapped(msg, &mapped), opts) {
 			continue
 		}
-
-		if filter.EnableSpacedBypass {
-			nospaceMessage := strings.Replace(message, " ", "", -1)
-			if strings.Contains(nospaceMessage, swear) {
-				trippedWords = append(trippedWords, swear)
+		found(swear, entry)
+	}
+	for _, name := range filter.sortedPatterns() {
+		if err := opts.err(); err != nil {
+			return scanResult{}, err
+		}
+		if limited() {
+			break
+		}
+		p := filter.patterns[name]
+		if !filter.enabled(p.opts, opts, now) {
+			continue
+		}
+		if len(filter.patternOccurrences(message, p, msg, &mapped)) > 0 {
+			found(name, p.opts)
+		}
+	}
+	for _, name := range filter.trippedRules(message) {
+		if limited() {
+			break
+		}
+		blocked++
+		candidates = append(candidates, candidate{rule: name, action: ActionBlock, kind: RuleBlock})
+	}
+	if filter.DetectPII {
+		for _, kind := range piiKinds(filter.findPII(msg)) {
+			if limited() {
+				break
 			}
+			blocked++
+			candidates = append(candidates, candidate{rule: kind.String(), action: ActionBlock, kind: RuleBlock})
 		}
 	}
 
-	if checkSpace && message == "" {
-		trippedWords = append(trippedWords, " ")
+	candidates = filter.overrule(msg, mapped, candidates, opts)
+	decision := filter.decide(candidates)
+	if opts.decision != nil {
+		*opts.decision = decision
+	}
+	result.tripped = make([]string, 0)
+	if decision.Action == ActionBlock {
+		for _, c := range candidates {
+			if c.action == ActionBlock {
+				result.tripped = append(result.tripped, c.rule)
+			}
+		}
 	}
 
+	if opts.record {
+		if filter.OnMatch != nil || filter.OnShadowMatch != nil {
+			found := append(append([]string(nil), result.shadow...), result.tripped...)
+			result.spans = filter.firstSpans(msg, mapped, found, opts)
+		}
+		if filter.OnNearMiss != nil {
+			result.nearMisses = filter.nearMisses(msg, &mapped, opts, now)
+		}
+		filter.record(msg, now, &result)
+	}
 	return
 }
 
-func (filter *SwearFilter) normalizeLeetSpeak(message string) string {
+// Normalize returns msg exactly as the matcher sees it after running it through every enabled normalization stage
+//
+// When the message contains ambiguous leet characters or elongated runs, every interpretation is returned, separated
+// by InterpretationSeparator.
+func (filter *SwearFilter) Normalize(msg string) (string, error) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
 
-	normalized := strings.ToLower(message)
+	return filter.normalize(msg, scanOptions{})
+}
 
-	// Handle multi-character replacements first
+// normalize runs msg through every enabled normalization stage
+func (filter *SwearFilter) normalize(msg string, opts scanOptions) (message string, err error) {
+	mapped, err := filter.normalizeMapped(msg, opts)
+	defer mapped.release()
+	return mapped.text, err
+}
 
-	for leet, normal := range multiCharLeet {
-		normalized = strings.ReplaceAll(normalized, leet, normal)
+// normalizeMapped runs msg through every stage of the normalization pipeline, keeping track of where in msg every
+// byte of the normalized message came from
+func (filter *SwearFilter) normalizeMapped(msg string, opts scanOptions) (message mappedText, err error) {
+	start := opts.startTiming()
+	message = newMappedText(msg)
+	pipeline := opts.normalizers
+	if pipeline == nil {
+		pipeline = filter.normalizers()
+	}
+	for _, normalizer := range pipeline {
+		if err := opts.err(); err != nil {
+			return mappedText{}, err
+		}
+		if _, leet := normalizer.(LeetSpeakNormalizer); leet && opts.disableLeetSpeak {
+			continue
+		}
+		normalized, err := normalizeWith(normalizer, message)
+		if err != nil {
+			return mappedText{}, err
+		}
+		//Stages build their output afresh, so unless this one changed nothing its input is no longer needed
+		if !normalized.shares(message) {
+			message.release()
+		}
+		message = normalized
+		opts.lap(normalizer.Stage(), &start)
 	}
+	return message, nil
+}
+
+// matchRule is what the matcher needs to know about a word besides its text
+type matchRule struct {
+	exceptions []string //Longer words the word must not match inside
+	boundaries bool     //Whether the word only matches as a whole word
+	distance   int      //How many edits away from the word a word of the message can be to match it, 0 for exact matches only
+	phonetic   string   //Code a word of the message must have under the filter's Phonetic algorithm to match, none if empty or without PhoneticBlocking
+
+	//The message before normalization and where the normalized one came from in it, when known, so leet speak
+	//turning punctuation into letters (ex: "hell!" -> "helli") doesn't break word boundaries
+	original string
+	source   *mappedText
+}
+
+// mapped returns the rule for matching in the given normalized message of original
+func (rule matchRule) mapped(original string, source *mappedText) matchRule {
+	rule.original, rule.source = original, source
+	return rule
+}
 
-	// Handle single character replacements
-	for leet, normal := range leetChars {
-		normalized = strings.ReplaceAll(normalized, leet, normal)
+// boundary reports whether the text from start to end of the normalized message stands on its own, either there or
+// in the original message
+func (rule matchRule) boundary(message string, start, end int) bool {
+	if wordBoundary(message, start, end) {
+		return true
 	}
+	if rule.source == nil {
+		return false
+	}
+	start, end = rule.source.span(start, end)
+	return wordBoundary(rule.original, start, end)
+}
 
-	var possibleStrings []string
-	for leet, possibilities := range ambiguousLeetMap {
-		if strings.Contains(normalized, leet) {
-			for _, replacement := range possibilities {
-				newStr := strings.ReplaceAll(normalized, leet, replacement)
-				possibleStrings = append(possibleStrings, newStr)
-			}
+// matches reports whether swear is found in the normalized message
+func (filter *SwearFilter) matches(message, swear string, rule matchRule, opts scanOptions) bool {
+	if contains(message, swear, rule) {
+		return true
+	}
+
+	if filter.EnableSpacedBypass && !opts.disableSpacedBypass {
+		if rule.boundaries {
+			return len(spacedOccurrences(message, swear, rule)) > 0
+		}
+		nospaceMessage := strings.Replace(message, " ", "", -1)
+		if contains(nospaceMessage, swear, rule) {
+			return true
 		}
 	}
+	if rule.distance <= 0 && rule.phonetic == "" {
+		return false
+	}
+	defer opts.timeFuzzy()()
+	if len(filter.fuzzyOccurrences(message, swear, rule)) > 0 {
+		return true
+	}
+	return len(filter.phoneticOccurrences(message, swear, rule)) > 0
+}
 
-	// Join all possible interpretations with spaces and check as one string
-	if len(possibleStrings) > 0 {
-		normalized = strings.Join(possibleStrings, " ")
+// occurrences returns the byte offsets swear occurs at in message, other than inside one of its exceptions or, if
+// the rule requires boundaries, inside a longer word
+func occurrences(message, swear string, rule matchRule) (offsets []int) {
+	if swear == "" {
+		return nil
+	}
+	for i := 0; i+len(swear) <= len(message); i++ {
+		j := strings.Index(message[i:], swear)
+		if j < 0 {
+			break
+		}
+		i += j
+		if rule.boundaries && !rule.boundary(message, i, i+len(swear)) {
+			continue
+		}
+		if !excepted(message, i, swear, rule.exceptions) {
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
 
+// contains reports whether swear occurs in message anywhere the rule lets it match
+func contains(message, swear string, rule matchRule) bool {
+	if len(rule.exceptions) == 0 && !rule.boundaries {
+		return strings.Contains(message, swear)
 	}
+	return len(occurrences(message, swear, rule)) > 0
+}
 
-	return normalized
+// excepted reports whether the occurrence of swear at byte offset at in message is part of one of the exceptions
+func excepted(message string, at int, swear string, exceptions []string) bool {
+	for _, exception := range exceptions {
+		for offset := 0; offset+len(swear) <= len(exception); offset++ {
+			k := strings.Index(exception[offset:], swear)
+			if k < 0 {
+				break
+			}
+			offset += k
+			start := at - offset
+			if start >= 0 && start+len(exception) <= len(message) && message[start:start+len(exception)] == exception {
+				return true
+			}
+		}
+	}
+	return false
 }
 
-// Add appends the given word to the uhohwords list
+// Add appends the given word to the uhohwords list, resetting any options previously set for it
 func (filter *SwearFilter) Add(badWords ...string) {
+	filter.AddWithOptions(WordOptions{}, badWords...)
+}
+
+// AddWithOptions appends the given words to the uhohwords list with the given per-word options
+func (filter *SwearFilter) AddWithOptions(opts WordOptions, badWords ...string) {
 	filter.mutex.Lock()
 	defer filter.mutex.Unlock()
 
+	filter.addWords(opts, badWords)
+}
+
+// addWords adds words to the uhohwords list with the given options, the filter's lock must be held
+func (filter *SwearFilter) addWords(opts WordOptions, badWords []string) {
 	if filter.BadWords == nil {
 		filter.BadWords = make(map[string]struct{})
 	}
+	if filter.entries == nil {
+		filter.entries = make(map[string]WordOptions)
+	}
 
+	if len(opts.Exceptions) > 0 {
+		exceptions := make([]string, len(opts.Exceptions))
+		for i, exception := range opts.Exceptions {
+			exceptions[i] = strings.ToLower(exception)
+		}
+		opts.Exceptions = exceptions
+	}
 	for _, word := range badWords {
 		filter.BadWords[word] = struct{}{}
+		filter.entries[word] = opts
 	}
+	filter.matcher = nil
+}
+
+// Options returns the per-word options of the given word, and whether it is in the uhohwords list
+func (filter *SwearFilter) Options(word string) (opts WordOptions, ok bool) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	if _, ok = filter.BadWords[word]; !ok {
+		return WordOptions{}, false
+	}
+	return filter.entries[word], true
 }
 
 // Delete deletes the given word from the uhohwords list
@@ -205,10 +561,92 @@ func (filter *SwearFilter) Delete(badWords ...string) {
 
 	for _, word := range badWords {
 		delete(filter.BadWords, word)
+		delete(filter.entries, word)
 	}
+	filter.matcher = nil
+}
+
+// DeleteByCategory deletes every word in the given category from the uhohwords list and returns how many were deleted
+func (filter *SwearFilter) DeleteByCategory(category string) (deleted int) {
+	return filter.deleteWhere(func(opts WordOptions) bool {
+		return opts.Category == category
+	})
+}
+
+// DeleteByTag deletes every word carrying the given tag from the uhohwords list and returns how many were deleted
+func (filter *SwearFilter) DeleteByTag(tag string) (deleted int) {
+	return filter.deleteWhere(func(opts WordOptions) bool {
+		for _, t := range opts.Tags {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	})
 }
 
-// Words return the uhohwords list
+// deleteWhere deletes every word whose options satisfy match
+func (filter *SwearFilter) deleteWhere(match func(opts WordOptions) bool) (deleted int) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	for word := range filter.BadWords {
+		if match(filter.entries[word]) {
+			delete(filter.BadWords, word)
+			delete(filter.entries, word)
+			deleted++
+		}
+	}
+	filter.matcher = nil
+	return deleted
+}
+
+// Clear empties the uhohwords list, the patterns, the co-occurrence rules and the allowlist in one step, so
+// concurrent checks see either every word or none and a cleared filter trips nothing
+//
+// The options, the link denylist and the stats are left alone, see Reset.
+func (filter *SwearFilter) Clear() {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	filter.BadWords = make(map[string]struct{})
+	filter.entries = nil
+	filter.patterns = nil
+	filter.rules = nil
+	filter.allowed = nil
+	filter.matcher = nil
+}
+
+// Reset restores the filter to how NewSwearFilter created it: every option, hook and the clock go back to their
+// defaults, and the uhohwords list, patterns, co-occurrence rules, allowlist, link denylist and stats are emptied
+func (filter *SwearFilter) Reset() {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	//Zero every exported field so options added in the future are covered too
+	fields := reflect.ValueOf(filter).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		if fields.Type().Field(i).PkgPath == "" {
+			fields.Field(i).Set(reflect.Zero(fields.Field(i).Type()))
+		}
+	}
+	filter.EnableSpacedBypass = filter.defaultSpacedBypass
+	filter.BadWords = make(map[string]struct{})
+	filter.entries = nil
+	filter.patterns = nil
+	filter.matcher = nil
+	filter.domains = nil
+	filter.rules = nil
+	filter.allowed = nil
+	filter.randomSalt = nil
+	filter.saltOnce = sync.Once{}
+
+	filter.stats.mutex.Lock()
+	filter.stats.buckets = nil
+	filter.stats.mutex.Unlock()
+}
+
+// Words return the uhohwords list, including monitor-only words but not the words that allow messages
 func (filter *SwearFilter) Words() (activeWords []string) {
 	filter.mutex.RLock()
 	defer filter.mutex.RUnlock()
@@ -218,6 +656,9 @@ func (filter *SwearFilter) Words() (activeWords []string) {
 	}
 
 	for word := range filter.BadWords {
+		if action, _ := filter.action(filter.entries[word]); action == ActionAllow {
+			continue
+		}
 		activeWords = append(activeWords, word)
 	}
 	return
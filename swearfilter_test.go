@@ -1,6 +1,8 @@
 package swearfilter
 
 import (
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -216,3 +218,175 @@ func TestCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalize(t *testing.T) {
+	filter := NewSwearFilter(false)
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lowercase", "HeLLo", "hello"},
+		{"marks", "fûçk", "fuck"},
+		{"leet", "$h0rt", "short"},
+		{"whitespace", "  a\t\tb  ", "ab"},
+		{"zero width", "f\u200buck", "fuck"},
+		{"ambiguous", "!t", "it\u2029lt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filter.Normalize(tt.input)
+			if err != nil {
+				t.Fatalf("Normalize failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	filter.DisableLeetSpeak = true
+	if got, _ := filter.Normalize("$h0rt"); got != "$h0rt" {
+		t.Errorf("got %q with leet speak disabled, want %q", got, "$h0rt")
+	}
+}
+
+func TestDeleteByCategoryAndTag(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.AddWithOptions(WordOptions{Category: "slur", Tags: []string{"community"}}, "a", "b")
+	filter.AddWithOptions(WordOptions{Category: "profanity", Tags: []string{"community", "es"}}, "c")
+	filter.AddWithOptions(WordOptions{Category: "profanity", Tags: []string{"es"}}, "d")
+
+	if deleted := filter.DeleteByTag("community"); deleted != 3 {
+		t.Errorf("got %d words deleted by tag, want %d", deleted, 3)
+	}
+	if deleted := filter.DeleteByCategory("profanity"); deleted != 1 {
+		t.Errorf("got %d words deleted by category, want %d", deleted, 1)
+	}
+	if deleted := filter.DeleteByCategory("missing"); deleted != 0 {
+		t.Errorf("got %d words deleted for a missing category, want none", deleted)
+	}
+	if words := filter.Words(); len(words) != 1 || words[0] != "fuck" {
+		t.Errorf("got words %v, want only the uncategorized word left", words)
+	}
+}
+
+func TestExceptions(t *testing.T) {
+	filter := NewSwearFilter(true)
+	filter.AddWithOptions(WordOptions{Exceptions: []string{"Assign", "assess", "asset", "bass"}}, "ass")
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"assign the ticket", false},
+		{"a bass guitar", false},
+		{"assets and assessments", false},
+		{"you ass", true},
+		{"assign it, you ass", true},
+		{"a s s", true},
+		{"a s s i g n", false},
+		{"@ssign", false},
+	}
+	for _, tt := range tests {
+		trippers, err := filter.Check(tt.input)
+		if err != nil {
+			t.Fatalf("Check(%q) failed: %v", tt.input, err)
+		}
+		if tripped := len(trippers) > 0; tripped != tt.expected {
+			t.Errorf("got tripped %t for %q, want %t", tripped, tt.input, tt.expected)
+		}
+	}
+
+	if matched, _, err := filter.TestWord("ass", "assign"); err != nil || matched {
+		t.Errorf("got TestWord matched %t, %v for an exception, want false", matched, err)
+	}
+}
+
+func TestClearAndReset(t *testing.T) {
+	filter := NewSwearFilter(true, "fuck", "shit")
+	filter.AddWithOptions(WordOptions{Shadow: true}, "darn")
+	filter.AddDomain("example.com")
+	filter.AddRule(CoOccurrence{A: "kill", B: "you"})
+	filter.AddAllowed("shitake")
+	filter.DisableLeetSpeak = true
+	filter.SampleRate = 0.5
+	filter.OnShadowMatch = func(MatchEvent) {}
+	filter.Check("darn")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			filter.Check("fuck shit")
+		}
+	}()
+	filter.Clear()
+	wg.Wait()
+	if words := filter.Words(); len(words) != 0 {
+		t.Errorf("got words %v after Clear, want none", words)
+	}
+	if !filter.DisableLeetSpeak || len(filter.Domains()) != 1 {
+		t.Errorf("got options or domains reset by Clear, want only the words emptied")
+	}
+	if rules, allowed := filter.Rules(), filter.AllowedWords(); len(rules) != 0 || len(allowed) != 0 {
+		t.Errorf("got rules %v and allowed words %v after Clear, want none", rules, allowed)
+	}
+	if trippers, _ := filter.Check("kill you"); len(trippers) != 0 {
+		t.Errorf("got trippers %v after Clear, want none", trippers)
+	}
+
+	filter.Add("fuck")
+	filter.Reset()
+	if len(filter.Words()) != 0 || len(filter.Domains()) != 0 || len(filter.Stats().Shadow) != 0 {
+		t.Errorf("got words %v, domains %v and stats %v after Reset, want all empty", filter.Words(), filter.Domains(), filter.Stats())
+	}
+	if filter.DisableLeetSpeak || filter.SampleRate != 0 || filter.OnShadowMatch != nil || !filter.EnableSpacedBypass {
+		t.Errorf("got options %+v after Reset, want the constructor defaults", filter)
+	}
+	filter.Add("fuck")
+	if trippers, _ := filter.Check("f u c k"); len(trippers) != 1 {
+		t.Errorf("got trippers %v after Reset, want the filter usable", trippers)
+	}
+}
+
+// benchmarkMessages are a chat-sized message and a page-sized one, clean apart from a single word, which is the
+// common case under load
+var benchmarkMessages = []struct {
+	name string
+	msg  string
+}{
+	{"small", "hey, are you coming to the game tonight? it was a hell of a match last week"},
+	{"large", strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt. ", 100) + "what the fuck"},
+}
+
+func BenchmarkCheck(b *testing.B) {
+	filter := NewSwearFilter(true, "fuck", "hell", "shit", "bitch", "ass", "damn")
+	for _, bm := range benchmarkMessages {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bm.msg)))
+			for i := 0; i < b.N; i++ {
+				if _, err := filter.Check(bm.msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNormalize(b *testing.B) {
+	filter := NewSwearFilter(false)
+	for _, bm := range benchmarkMessages {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bm.msg)))
+			for i := 0; i < b.N; i++ {
+				if _, err := filter.Normalize(bm.msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
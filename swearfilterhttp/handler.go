@@ -0,0 +1,197 @@
+// Package swearfilterhttp serves a shared swear filter over HTTP: a JSON moderation API to check and censor text and
+// manage the word list, and middleware rejecting or redacting request bodies with blocked words
+package swearfilterhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"swearfilter"
+)
+
+// MaxBodyBytes is the largest request body the handler and middleware read, larger ones are refused with 413
+const MaxBodyBytes = 1 << 20
+
+// Handler serves the moderation API backed by a shared filter:
+//
+//	POST   /check              {"text": "..."} -> {"tripped": true, "words": [...], "matches": [...]}
+//	POST   /censor             {"text": "..."} -> {"text": "...", "words": [...]}
+//	GET    /words              -> {"words": [...]}
+//	POST   /words              {"words": [...]} adds the words
+//	DELETE /words?word=a&word=b deletes the words
+//
+// Errors are answered with their status and {"error": "..."}. Mount it under a prefix with http.StripPrefix.
+//
+// The handler doesn't authenticate its callers, so adding and deleting words is refused with 403 unless
+// EnableWordManagement is set, which should only be done behind authentication or on a trusted network.
+type Handler struct {
+	EnableWordManagement bool //Accepts changes to the word list, refused with 403 by default
+
+	filter *swearfilter.SwearFilter
+	mux    *http.ServeMux
+}
+
+// NewHandler returns a Handler serving the API over filter
+func NewHandler(filter *swearfilter.SwearFilter) *Handler {
+	handler := &Handler{filter: filter, mux: http.NewServeMux()}
+	handler.mux.HandleFunc("/check", handler.serveCheck)
+	handler.mux.HandleFunc("/censor", handler.serveCensor)
+	handler.mux.HandleFunc("/words", handler.serveWords)
+	return handler
+}
+
+// ServeHTTP serves the API
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler.mux.ServeHTTP(w, r)
+}
+
+type textRequest struct {
+	Text string `json:"text"`
+}
+
+type wordsRequest struct {
+	Words []string `json:"words"`
+}
+
+type match struct {
+	Word  string `json:"word"`
+	Kind  string `json:"kind"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+type checkResponse struct {
+	Tripped bool     `json:"tripped"`
+	Words   []string `json:"words"`
+	Matches []match  `json:"matches"`
+}
+
+type censorResponse struct {
+	Text  string   `json:"text"`
+	Words []string `json:"words"`
+}
+
+type errorResponse struct {
+	Error string   `json:"error"`
+	Words []string `json:"words,omitempty"`
+}
+
+func (handler *Handler) serveCheck(w http.ResponseWriter, r *http.Request) {
+	var req textRequest
+	if !decodeRequest(w, r, http.MethodPost, &req) {
+		return
+	}
+	result, err := handler.filter.Inspect(req.Text)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := checkResponse{Tripped: len(result.Words) > 0, Words: result.Words, Matches: make([]match, len(result.Matches))}
+	for i, m := range result.Matches {
+		resp.Matches[i] = match{Word: m.Word, Kind: m.Kind.String(), Start: m.Start, End: m.End}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (handler *Handler) serveCensor(w http.ResponseWriter, r *http.Request) {
+	var req textRequest
+	if !decodeRequest(w, r, http.MethodPost, &req) {
+		return
+	}
+	censored, words, err := handler.filter.Censor(req.Text)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, censorResponse{Text: censored, Words: words})
+}
+
+func (handler *Handler) serveWords(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && !handler.EnableWordManagement {
+		writeError(w, http.StatusForbidden, errors.New("word management is not enabled"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		words := handler.filter.Words()
+		sort.Strings(words)
+		if words == nil {
+			words = []string{}
+		}
+		writeJSON(w, http.StatusOK, wordsRequest{Words: words})
+	case http.MethodPost:
+		var req wordsRequest
+		if !decodeRequest(w, r, http.MethodPost, &req) {
+			return
+		}
+		words, err := cleanWords(req.Words)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		handler.filter.Add(words...)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		words, err := cleanWords(r.URL.Query()["word"])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		handler.filter.Delete(words...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// cleanWords lowercases and trims the words, which must not be empty
+func cleanWords(words []string) ([]string, error) {
+	if len(words) == 0 {
+		return nil, errors.New("no words given")
+	}
+	cleaned := make([]string, len(words))
+	for i, word := range words {
+		if cleaned[i] = strings.ToLower(strings.TrimSpace(word)); cleaned[i] == "" {
+			return nil, errors.New("empty word")
+		}
+	}
+	return cleaned, nil
+}
+
+// decodeRequest decodes the JSON body of r into v, answering the request with an error and returning false if the
+// method isn't the expected one or the body is invalid
+func decodeRequest(w http.ResponseWriter, r *http.Request, method string, v interface{}) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return false
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
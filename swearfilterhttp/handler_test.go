@@ -0,0 +1,90 @@
+package swearfilterhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"swearfilter"
+)
+
+func serve(handler http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return recorder
+}
+
+func TestHandlerCheck(t *testing.T) {
+	handler := NewHandler(swearfilter.NewSwearFilter(false, "shit"))
+
+	resp := serve(handler, http.MethodPost, "/check", `{"text": "oh SH1T"}`)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200: %s", resp.Code, resp.Body)
+	}
+	var got checkResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	expected := checkResponse{Tripped: true, Words: []string{"shit"}, Matches: []match{{Word: "shit", Kind: "leet", Start: 3, End: 7}}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %+v, want %+v", got, expected)
+	}
+
+	resp = serve(handler, http.MethodPost, "/censor", `{"text": "oh shit"}`)
+	if resp.Code != http.StatusOK || !strings.Contains(resp.Body.String(), `"text":"oh ****"`) {
+		t.Errorf("got %d %s from censor, want the censored text", resp.Code, resp.Body)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	handler := NewHandler(swearfilter.NewSwearFilter(false, "shit"))
+	handler.EnableWordManagement = true
+
+	tests := []struct {
+		method, target, body string
+		status               int
+	}{
+		{http.MethodGet, "/check", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/check", `{"text": `, http.StatusBadRequest},
+		{http.MethodPost, "/check", `{"message": "hi"}`, http.StatusBadRequest},
+		{http.MethodPost, "/check", `{"text": "` + strings.Repeat("a", MaxBodyBytes) + `"}`, http.StatusRequestEntityTooLarge},
+		{http.MethodPost, "/words", `{"words": []}`, http.StatusBadRequest},
+		{http.MethodPut, "/words", "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/nothing", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if resp := serve(handler, tt.method, tt.target, tt.body); resp.Code != tt.status {
+			t.Errorf("%s %s got status %d, want %d", tt.method, tt.target, resp.Code, tt.status)
+		}
+	}
+}
+
+func TestHandlerWords(t *testing.T) {
+	filter := swearfilter.NewSwearFilter(false, "shit")
+	handler := NewHandler(filter)
+
+	if resp := serve(handler, http.MethodPost, "/words", `{"words": ["hell"]}`); resp.Code != http.StatusForbidden {
+		t.Errorf("got status %d by default, want word management refused with 403", resp.Code)
+	}
+	if resp := serve(handler, http.MethodDelete, "/words?word=shit", ""); resp.Code != http.StatusForbidden {
+		t.Errorf("got status %d deleting by default, want 403", resp.Code)
+	}
+	if resp := serve(handler, http.MethodGet, "/words", ""); resp.Code != http.StatusOK {
+		t.Errorf("got status %d listing by default, want 200", resp.Code)
+	}
+
+	handler.EnableWordManagement = true
+	if resp := serve(handler, http.MethodPost, "/words", `{"words": ["Crap", "fuck"]}`); resp.Code != http.StatusNoContent {
+		t.Fatalf("got status %d adding words, want 204: %s", resp.Code, resp.Body)
+	}
+	if resp := serve(handler, http.MethodDelete, "/words?word=shit", ""); resp.Code != http.StatusNoContent {
+		t.Fatalf("got status %d deleting a word, want 204: %s", resp.Code, resp.Body)
+	}
+	resp := serve(handler, http.MethodGet, "/words", "")
+	if body := strings.TrimSpace(resp.Body.String()); body != `{"words":["crap","fuck"]}` {
+		t.Errorf("got %s, want the updated list", body)
+	}
+}
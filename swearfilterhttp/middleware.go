@@ -0,0 +1,84 @@
+package swearfilterhttp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"swearfilter"
+)
+
+// Reject returns middleware refusing requests whose body trips filter with 422 and {"error": "...", "words": [...]},
+// passing the others on with their body intact
+func Reject(filter *swearfilter.SwearFilter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, ok := readBody(w, r)
+			if !ok {
+				return
+			}
+			words, err := filter.Check(string(body))
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			if len(words) > 0 {
+				writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: "request contains blocked words", Words: words})
+				return
+			}
+			setBody(r, body)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Redact returns middleware censoring request bodies with filter (see SwearFilter.Censor) before passing them on
+//
+// The body is censored as plain text whatever its content type; a JSON body keeps its structure unless a tripped word
+// spans its punctuation, such as a word spaced out over several strings with the spaced bypass.
+func Redact(filter *swearfilter.SwearFilter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, ok := readBody(w, r)
+			if !ok {
+				return
+			}
+			censored, _, err := filter.Censor(string(body))
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			setBody(r, []byte(censored))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// readBody reads the body of r, answering the request with an error and returning false if it can't be read
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Body == nil {
+		return nil, true
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodyBytes+1))
+	r.Body.Close()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	if len(body) > MaxBodyBytes {
+		writeError(w, http.StatusRequestEntityTooLarge, errors.New("request body too large"))
+		return nil, false
+	}
+	return body, true
+}
+
+// setBody replaces the body of r, updating its length
+func setBody(r *http.Request, body []byte) {
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	if r.Header.Get("Content-Length") != "" {
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+}
@@ -0,0 +1,46 @@
+package swearfilterhttp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"swearfilter"
+)
+
+// echo answers with the request body it received
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if r.ContentLength != int64(len(body)) {
+		http.Error(w, "wrong content length", http.StatusInternalServerError)
+		return
+	}
+	w.Write(body)
+})
+
+func TestReject(t *testing.T) {
+	handler := Reject(swearfilter.NewSwearFilter(false, "shit"))(echo)
+
+	if resp := serve(handler, http.MethodPost, "/", `{"comment": "nice"}`); resp.Code != http.StatusOK || resp.Body.String() != `{"comment": "nice"}` {
+		t.Errorf("got %d %s for a clean body, want it passed on", resp.Code, resp.Body)
+	}
+	resp := serve(handler, http.MethodPost, "/", `{"comment": "shit"}`)
+	if resp.Code != http.StatusUnprocessableEntity || !strings.Contains(resp.Body.String(), `"words":["shit"]`) {
+		t.Errorf("got %d %s for a blocked body, want 422 with the words", resp.Code, resp.Body)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	filter := swearfilter.NewSwearFilter(false, "shit")
+	filter.CensorFunc = func(string) string { return "[redacted]" }
+	handler := Redact(filter)(echo)
+
+	resp := serve(handler, http.MethodPost, "/", `{"comment": "oh shit"}`)
+	if resp.Code != http.StatusOK || resp.Body.String() != `{"comment": "oh [redacted]"}` {
+		t.Errorf("got %d %s, want the body redacted", resp.Code, resp.Body)
+	}
+	if resp := serve(handler, http.MethodPost, "/", strings.Repeat("a", MaxBodyBytes+1)); resp.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d for a huge body, want 413", resp.Code)
+	}
+}
@@ -0,0 +1,72 @@
+package swearfilter
+
+import (
+	"time"
+)
+
+// Stages of the pipeline reported in Result.Timings
+const (
+	StageLowercase   = "lowercase"   //Lowercasing the message
+	StagePunycode    = "punycode"    //Decoding punycode labels
+	StageConfusables = "confusables" //Folding lookalike characters to the letters they imitate
+	StageRepeat      = "repeat"      //Collapsing runs of the same character
+	StageLeet        = "leet"        //Translating leet speak
+	StageNormalize   = "normalize"   //Stripping marks from letters
+	StageWhitespace  = "whitespace"  //Converting tabs, stripping zero-width spaces and collapsing whitespace
+	StageMatch       = "match"       //Matching the normalized message against the wordlist, apart from fuzzy and phonetic matching
+	StageFuzzy       = "fuzzy"       //Fuzzy and phonetic matching, when any word is matched either way
+	StagePII         = "pii"         //Detecting personal information
+	StageLinks       = "links"       //Extracting links and checking them against the domain denylist
+	StageSignals     = "signals"     //Measuring shouting and flooding
+)
+
+// StageTiming is how long a single pipeline stage took during Inspect
+type StageTiming struct {
+	Stage    string
+	Duration time.Duration
+}
+
+// startTiming returns the current time if the scan collects timings, or the zero time if it doesn't
+func (opts scanOptions) startTiming() time.Time {
+	if opts.timings == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// lap records the time since *start under stage and restarts the clock, it does nothing unless the scan collects timings
+func (opts scanOptions) lap(stage string, start *time.Time) {
+	if opts.timings == nil {
+		return
+	}
+	now := time.Now()
+	*opts.timings = append(*opts.timings, StageTiming{Stage: stage, Duration: now.Sub(*start)})
+	*start = now
+}
+
+// lapMatch records the time since *start under StageMatch like lap, moving the time spent in fuzzy and phonetic
+// matching out of it into StageFuzzy, after it
+func (opts scanOptions) lapMatch(start *time.Time) {
+	if opts.timings == nil {
+		return
+	}
+	opts.lap(StageMatch, start)
+	if opts.fuzzy != nil && opts.fuzzy.Stage != "" {
+		timings := *opts.timings
+		timings[len(timings)-1].Duration -= opts.fuzzy.Duration
+		*opts.timings = append(timings, *opts.fuzzy)
+	}
+}
+
+// timeFuzzy starts timing fuzzy and phonetic matching, the returned function adding the time since to StageFuzzy; it
+// does nothing unless the scan collects timings
+func (opts scanOptions) timeFuzzy() func() {
+	if opts.fuzzy == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		opts.fuzzy.Stage = StageFuzzy
+		opts.fuzzy.Duration += time.Since(start)
+	}
+}
@@ -0,0 +1,57 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestInspectTimings(t *testing.T) {
+	filter := NewSwearFilter(false, "fuck")
+	filter.DetectPII = true
+
+	result, err := filter.Inspect("what the fuck")
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	expected := []string{StageLowercase, StagePunycode, StageConfusables, StageRepeat, StageLeet, StageNormalize, StageWhitespace, StageMatch, StagePII, StageLinks}
+	if len(result.Timings) != len(expected) {
+		t.Fatalf("got timings %v, want stages %v", result.Timings, expected)
+	}
+	for i, timing := range result.Timings {
+		if timing.Stage != expected[i] {
+			t.Errorf("got stage %s at %d, want %s", timing.Stage, i, expected[i])
+		}
+		if timing.Duration < 0 {
+			t.Errorf("got negative duration for %s", timing.Stage)
+		}
+	}
+
+	filter.DisableLeetSpeak = true
+	filter.DetectPII = false
+	result, _ = filter.Inspect("what the fuck")
+	for _, timing := range result.Timings {
+		if timing.Stage == StageLeet || timing.Stage == StagePII {
+			t.Errorf("got timing for disabled stage %s, want none", timing.Stage)
+		}
+		if timing.Stage == StageFuzzy {
+			t.Errorf("got timing for fuzzy matching with MaxEditDistance unset, want none")
+		}
+	}
+
+	filter.MaxEditDistance = 1
+	result, _ = filter.Inspect("what the fcuk")
+	fuzzy := -1
+	for i, timing := range result.Timings {
+		if timing.Stage == StageFuzzy {
+			fuzzy = i
+			if timing.Duration < 0 {
+				t.Errorf("got negative duration for fuzzy matching")
+			}
+		}
+	}
+	if fuzzy < 1 || result.Timings[fuzzy-1].Stage != StageMatch {
+		t.Errorf("got timings %v, want a fuzzy stage right after matching", result.Timings)
+	}
+	if len(result.Words) != 1 {
+		t.Errorf("got words %v, want the misspelling matched", result.Words)
+	}
+}
@@ -0,0 +1,27 @@
+package swearfilter
+
+import (
+	"strings"
+)
+
+// CheckUsername checks a username the way it will be stored by the account system
+//
+// If UsernameProfile is set, the name is first prepared and enforced with it, and the result is returned as
+// username so callers can store exactly what was checked; names the profile rejects return its error. Separators
+// common in usernames (_ . -) are then treated as spaces, so they are only joined back up by the spaced bypass.
+func (filter *SwearFilter) CheckUsername(name string) (username string, trippedWords []string, err error) {
+	username = name
+	if filter.UsernameProfile != nil {
+		username, err = filter.UsernameProfile.String(name)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	spaced := strings.NewReplacer("_", " ", ".", " ", "-", " ").Replace(username)
+	trippedWords, err = filter.check(spaced, scanOptions{key: username})
+	if err != nil {
+		return "", nil, err
+	}
+	return username, trippedWords, nil
+}
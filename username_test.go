@@ -0,0 +1,32 @@
+package swearfilter
+
+import (
+	"testing"
+
+	"golang.org/x/text/secure/precis"
+)
+
+func TestCheckUsername(t *testing.T) {
+	filter := NewSwearFilter(true, "shit")
+
+	username, trippers, err := filter.CheckUsername("xX_s_h_i_t_Xx")
+	if err != nil {
+		t.Fatalf("CheckUsername failed: %v", err)
+	}
+	if username != "xX_s_h_i_t_Xx" || len(trippers) != 1 {
+		t.Errorf("got username %q and trippers %v without a profile, want the name unchanged and tripped", username, trippers)
+	}
+
+	filter.UsernameProfile = precis.UsernameCaseMapped
+	username, trippers, err = filter.CheckUsername("ＳＨＩＴlord")
+	if err != nil {
+		t.Fatalf("CheckUsername failed: %v", err)
+	}
+	if username != "shitlord" || len(trippers) != 1 {
+		t.Errorf("got username %q and trippers %v, want the profile's form and tripped", username, trippers)
+	}
+
+	if _, _, err := filter.CheckUsername("has space"); err == nil {
+		t.Errorf("got no error for a name the profile rejects, want one")
+	}
+}
@@ -0,0 +1,202 @@
+package swearfilter
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// VariantKind is the kind of obfuscation a Variant applies
+type VariantKind string
+
+// Kinds of obfuscation SuggestVariants applies
+const (
+	VariantLeet      VariantKind = "leet"      //Letters swapped for lookalike digits and symbols (ex: sh1t)
+	VariantSpaced    VariantKind = "spaced"    //Letters separated by spaces, punctuation or invisible characters (ex: s.h.i.t)
+	VariantHomoglyph VariantKind = "homoglyph" //Letters swapped for accented, foreign-script or fullwidth lookalikes (ex: ѕhit)
+	VariantRepeat    VariantKind = "repeat"    //Letters repeated to stretch the word (ex: shiiit)
+)
+
+// Variant is an obfuscated spelling of a word
+type Variant struct {
+	Text   string
+	Kind   VariantKind
+	Caught bool //Whether the filter, as currently configured, finds the word in Text
+}
+
+// accentHomoglyphs maps letters to accented forms, which mark stripping folds back
+var accentHomoglyphs = map[rune][]rune{
+	'a': {'à', 'á', 'ä'},
+	'c': {'ç'},
+	'e': {'é', 'ë'},
+	'i': {'í', 'ï'},
+	'n': {'ñ'},
+	'o': {'ó', 'ö'},
+	'u': {'ú', 'ü'},
+	'y': {'ý'},
+}
+
+// scriptHomoglyphs maps letters to Cyrillic and Greek code points that look the same
+var scriptHomoglyphs = map[rune][]rune{
+	'a': {'а', 'α'},
+	'c': {'с'},
+	'e': {'е'},
+	'h': {'һ'},
+	'i': {'і', 'ι'},
+	'k': {'к', 'κ'},
+	'o': {'о', 'ο'},
+	'p': {'р', 'ρ'},
+	's': {'ѕ'},
+	'u': {'υ'},
+	'v': {'ν'},
+	'x': {'х', 'χ'},
+	'y': {'у'},
+}
+
+var variantSeparators = []string{" ", ".", "-", "_", "*", "\t", "\u200b"}
+
+// SuggestVariants returns up to n obfuscated spellings of word, both ones the filter catches and ones it misses, so
+// list maintainers can audit how well a word is covered before relying on it
+//
+// Kinds of variants are interleaved so that even a small n covers every kind of obfuscation.
+func (filter *SwearFilter) SuggestVariants(word string, n int) (variants []Variant, err error) {
+	word = strings.ToLower(word)
+	seen := map[string]struct{}{word: {}}
+	queues := variantQueues(word, leetAlternatives(filter.leetSpeak()))
+
+	for len(variants) < n {
+		added := false
+		for _, queue := range queues {
+			for len(queue.texts) > 0 && len(variants) < n {
+				text := queue.texts[0]
+				queue.texts = queue.texts[1:]
+				if _, ok := seen[text]; ok {
+					continue
+				}
+				seen[text] = struct{}{}
+				caught, err := filter.catches(word, text)
+				if err != nil {
+					return nil, err
+				}
+				variants = append(variants, Variant{Text: text, Kind: queue.kind, Caught: caught})
+				added = true
+				break
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return variants, nil
+}
+
+type variantQueue struct {
+	kind  VariantKind
+	texts []string
+}
+
+// variantQueues generates the candidate variants of word for each kind of obfuscation, leet being the spellings each
+// letter can take
+func variantQueues(word string, leet map[rune][]string) []*variantQueue {
+	letters := []rune(word)
+
+	leetQueue := &variantQueue{kind: VariantLeet}
+	full := make([]string, len(letters))
+	for i, r := range letters {
+		full[i] = string(r)
+		for _, alt := range leet[r] {
+			leetQueue.texts = append(leetQueue.texts, string(letters[:i])+alt+string(letters[i+1:]))
+		}
+		if alts := leet[r]; len(alts) > 0 {
+			full[i] = alts[0]
+		}
+	}
+	leetQueue.texts = append([]string{strings.Join(full, "")}, leetQueue.texts...)
+
+	spacedQueue := &variantQueue{kind: VariantSpaced}
+	for _, separator := range variantSeparators {
+		spacedQueue.texts = append(spacedQueue.texts, joinRunes(letters, separator))
+	}
+
+	homoglyphQueue := &variantQueue{kind: VariantHomoglyph}
+	fullwidth := make([]rune, len(letters))
+	for i, r := range letters {
+		fullwidth[i] = r
+		if r >= '!' && r <= '~' {
+			fullwidth[i] = r + 0xFEE0
+		}
+	}
+	homoglyphQueue.texts = append(homoglyphQueue.texts, string(fullwidth))
+	for _, table := range []map[rune][]rune{accentHomoglyphs, scriptHomoglyphs} {
+		for i, r := range letters {
+			for _, alt := range table[r] {
+				homoglyphQueue.texts = append(homoglyphQueue.texts, string(letters[:i])+string(alt)+string(letters[i+1:]))
+			}
+		}
+	}
+
+	repeatQueue := &variantQueue{kind: VariantRepeat}
+	for i, r := range letters {
+		repeatQueue.texts = append(repeatQueue.texts, string(letters[:i+1])+strings.Repeat(string(r), 2)+string(letters[i+1:]))
+	}
+
+	return []*variantQueue{leetQueue, spacedQueue, homoglyphQueue, repeatQueue}
+}
+
+// leetSpeak returns the leet speak translation messages go through: the first one in Normalizers when set, or else
+// LeetSpeak
+func (filter *SwearFilter) leetSpeak() LeetSpeakNormalizer {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	if filter.Normalizers != nil {
+		for _, normalizer := range filter.Normalizers {
+			if leet, ok := normalizer.(LeetSpeakNormalizer); ok {
+				return leet
+			}
+		}
+	}
+	return filter.LeetSpeak
+}
+
+// leetAlternatives inverts the tables of the leet speak translation, its maps applied to the built-in ones, into the
+// spellings each letter can take, in a stable order
+func leetAlternatives(n LeetSpeakNormalizer) map[rune][]string {
+	alternatives := make(map[rune][]string)
+	add := func(leet, normal string) {
+		r, _ := utf8.DecodeRuneInString(normal)
+		alternatives[r] = append(alternatives[r], leet)
+	}
+	for leet, normal := range mergeLeet(leetChars, n.Chars) {
+		add(leet, normal)
+	}
+	for leet, normal := range mergeLeet(multiCharLeet, n.Sequences) {
+		add(leet, normal)
+	}
+	for leet, normals := range n.ambiguous() {
+		for _, normal := range normals {
+			add(leet, normal)
+		}
+	}
+	for r := range alternatives {
+		sort.Strings(alternatives[r])
+	}
+	return alternatives
+}
+
+func joinRunes(letters []rune, separator string) string {
+	parts := make([]string, len(letters))
+	for i, r := range letters {
+		parts[i] = string(r)
+	}
+	return strings.Join(parts, separator)
+}
+
+// catches reports whether word would be found in text under the filter's current normalization settings
+func (filter *SwearFilter) catches(word, text string) (bool, error) {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	_, found, err := filter.matchWord(word, text)
+	return found, err
+}
@@ -0,0 +1,86 @@
+package swearfilter
+
+import (
+	"testing"
+)
+
+func TestSuggestVariants(t *testing.T) {
+	filter := NewSwearFilter(true, "shit")
+	variants, err := filter.SuggestVariants("shit", 200)
+	if err != nil {
+		t.Fatalf("SuggestVariants failed: %v", err)
+	}
+
+	kinds := make(map[VariantKind]int)
+	byText := make(map[string]Variant)
+	for _, variant := range variants {
+		kinds[variant.Kind]++
+		if _, ok := byText[variant.Text]; ok || variant.Text == "shit" {
+			t.Errorf("got duplicate or unchanged variant %q", variant.Text)
+		}
+		byText[variant.Text] = variant
+	}
+	for _, kind := range []VariantKind{VariantLeet, VariantSpaced, VariantHomoglyph, VariantRepeat} {
+		if kinds[kind] == 0 {
+			t.Errorf("got no %s variants, want some", kind)
+		}
+	}
+
+	expected := map[string]bool{
+		"$hit":    true,
+		"s h i t": true,
+		"shít":    true,
+		"ѕhit":    true,
+		"s.h.i.t": false,
+	}
+	for text, caught := range expected {
+		variant, ok := byText[text]
+		if !ok {
+			t.Errorf("got no variant %q, want one", text)
+			continue
+		}
+		if variant.Caught != caught {
+			t.Errorf("got caught %t for %q, want %t", variant.Caught, text, caught)
+		}
+	}
+
+	few, _ := filter.SuggestVariants("shit", 4)
+	if len(few) != 4 || few[0].Kind != VariantLeet || few[1].Kind != VariantSpaced || few[2].Kind != VariantHomoglyph || few[3].Kind != VariantRepeat {
+		t.Errorf("got variants %v, want one of each kind", few)
+	}
+}
+
+func TestSuggestVariantsCustomLeet(t *testing.T) {
+	filter := NewSwearFilter(false, "shit")
+	filter.LeetSpeak.Chars = map[string]string{"¡": "i", "$": ""}
+	variants, err := filter.SuggestVariants("shit", 200)
+	if err != nil {
+		t.Fatalf("SuggestVariants failed: %v", err)
+	}
+	byText := make(map[string]Variant)
+	for _, variant := range variants {
+		byText[variant.Text] = variant
+	}
+	if variant, ok := byText["sh¡t"]; !ok || !variant.Caught {
+		t.Errorf("got %+v for the custom substitution, want it offered and caught", variant)
+	}
+	if _, ok := byText["$hit"]; ok {
+		t.Error("got a variant with a removed substitution, want none")
+	}
+
+	filter.LeetSpeak = LeetSpeakNormalizer{}
+	filter.Normalizers = []Normalizer{LowercaseNormalizer{}, LeetSpeakNormalizer{Chars: map[string]string{"¡": "i"}}}
+	variants, err = filter.SuggestVariants("shit", 200)
+	if err != nil {
+		t.Fatalf("SuggestVariants failed: %v", err)
+	}
+	found := false
+	for _, variant := range variants {
+		if variant.Text == "sh¡t" {
+			found = variant.Caught
+		}
+	}
+	if !found {
+		t.Error("got no caught variant from the pipeline's leet speak translation, want one")
+	}
+}
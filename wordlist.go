@@ -0,0 +1,591 @@
+package swearfilter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WordListFormat is the file format of a word list
+type WordListFormat int
+
+// Formats a word list can be read and written in
+const (
+	WordListJSON WordListFormat = iota
+	WordListYAML
+)
+
+// wordListFile is the structure of a word list file
+type wordListFile struct {
+	Words []wordListEntry `json:"words"`
+}
+
+// wordListEntry is a word of a word list file and its options, every option is optional
+type wordListEntry struct {
+	Word       string   `json:"word"`
+	Action     string   `json:"action,omitempty"`   //block, shadow or allow, see WordOptions.Action
+	Severity   string   `json:"severity,omitempty"` //mild, moderate or severe
+	Category   string   `json:"category,omitempty"`
+	Weight     float64  `json:"weight,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Language   string   `json:"language,omitempty"`
+	Exceptions []string `json:"exceptions,omitempty"`
+	WholeWord  bool     `json:"whole_word,omitempty"` //See WordOptions.RequireWordBoundaries
+	Rollout    int      `json:"rollout,omitempty"`    //See WordOptions.RolloutPercent
+	Fuzzy      int      `json:"fuzzy,omitempty"`      //See WordOptions.MaxEditDistance
+}
+
+// LoadWordList reads the word list file at path, in JSON or YAML, and replaces the uhohwords list with it, see
+// LoadWordListFromReader
+func (filter *SwearFilter) LoadWordList(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return filter.LoadWordListFromReader(file)
+}
+
+// LoadWordListFromReader reads a word list from r and replaces the uhohwords list and every word's options with it
+//
+// The list is a JSON object or YAML document holding a words list, each word with its options:
+//
+//	words:
+//	  - word: fuck
+//	    severity: severe
+//	    category: profanity
+//	    language: en
+//	    tags: [core, en]
+//	  - word: ass
+//	    exceptions: [assign, assess, asset]
+//	    whole_word: true
+//
+// The options are action (block, shadow or allow), severity (mild, moderate or severe), category, weight, tags,
+// language, exceptions, whole_word, rollout and fuzzy, see WordOptions. Input starting with { is read as JSON, anything else
+// as YAML; only the block and flow styles shown above are understood, not anchors or multi-line strings.
+//
+// The whole list is parsed before anything is changed and swapped in in one step, so checks running concurrently see
+// either the old list or the new one: call it again whenever the file changes to hot-reload it. Nothing is changed
+// if the list is invalid. Patterns, co-occurrence rules, the allowlist and the filter's options are left alone.
+func (filter *SwearFilter) LoadWordListFromReader(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var list wordListFile
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err = decoder.Decode(&list); err != nil {
+			return fmt.Errorf("swearfilter: word list: %v", err)
+		}
+	} else if list, err = parseWordListYAML(data); err != nil {
+		return fmt.Errorf("swearfilter: word list: %v", err)
+	}
+
+	badWords := make(map[string]struct{}, len(list.Words))
+	entries := make(map[string]WordOptions, len(list.Words))
+	for i, entry := range list.Words {
+		word := strings.ToLower(entry.Word)
+		opts, err := entry.options()
+		if err == nil {
+			err = checkTerm(word)
+		}
+		if err != nil {
+			return fmt.Errorf("swearfilter: word list entry %d: %v", i+1, err)
+		}
+		badWords[word] = struct{}{}
+		entries[word] = opts
+	}
+
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+
+	filter.BadWords = badWords
+	filter.entries = entries
+	filter.matcher = nil
+	return nil
+}
+
+// SaveWordList writes the uhohwords list and every word's options to the file at path, in YAML if its extension is
+// .yaml or .yml and in JSON otherwise, see WriteWordList
+func (filter *SwearFilter) SaveWordList(path string) error {
+	format := WordListJSON
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		format = WordListYAML
+	}
+
+	var buf bytes.Buffer
+	if err := filter.WriteWordList(&buf, format); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// WriteWordList writes the uhohwords list and every word's options to w in the given format, sorted by word, in a
+// form LoadWordListFromReader reads back
+//
+// Schedules aren't written, as they can't be represented in a file. Monitor-only flags set with WordOptions.Shadow are
+// written as the shadow action, which reads back to the same behaviour.
+func (filter *SwearFilter) WriteWordList(w io.Writer, format WordListFormat) error {
+	filter.mutex.RLock()
+	list := wordListFile{Words: make([]wordListEntry, 0, len(filter.BadWords))}
+	for word := range filter.BadWords {
+		list.Words = append(list.Words, newWordListEntry(word, filter.entries[word]))
+	}
+	filter.mutex.RUnlock()
+	sort.Slice(list.Words, func(i, j int) bool {
+		return list.Words[i].Word < list.Words[j].Word
+	})
+
+	switch format {
+	case WordListJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "\t")
+		return encoder.Encode(list)
+	case WordListYAML:
+		return writeWordListYAML(w, list)
+	}
+	return fmt.Errorf("swearfilter: unknown word list format %d", format)
+}
+
+func newWordListEntry(word string, opts WordOptions) wordListEntry {
+	entry := wordListEntry{
+		Word:       word,
+		Category:   opts.Category,
+		Weight:     opts.Weight,
+		Tags:       opts.Tags,
+		Language:   opts.Language,
+		Exceptions: opts.Exceptions,
+		WholeWord:  opts.RequireWordBoundaries,
+		Rollout:    opts.RolloutPercent,
+		Fuzzy:      opts.MaxEditDistance,
+	}
+	if opts.Shadow {
+		entry.Action = ActionShadow.String()
+	} else if opts.Action != ActionDefault {
+		entry.Action = opts.Action.String()
+	}
+	if opts.Severity != SeverityDefault {
+		entry.Severity = opts.Severity.String()
+	}
+	return entry
+}
+
+// options returns the word options the entry describes
+func (entry wordListEntry) options() (opts WordOptions, err error) {
+	opts = WordOptions{
+		Category:              entry.Category,
+		Weight:                entry.Weight,
+		Tags:                  entry.Tags,
+		Language:              entry.Language,
+		RequireWordBoundaries: entry.WholeWord,
+		RolloutPercent:        entry.Rollout,
+		MaxEditDistance:       entry.Fuzzy,
+	}
+	if entry.Action != "" {
+		if opts.Action = ruleActions[entry.Action]; opts.Action == ActionDefault {
+			return WordOptions{}, fmt.Errorf("unknown action %q", entry.Action)
+		}
+	}
+	if entry.Severity != "" {
+		if opts.Severity, err = parseSeverity(entry.Severity); err != nil {
+			return WordOptions{}, err
+		}
+	}
+	if entry.Rollout < 0 || entry.Rollout > 100 {
+		return WordOptions{}, fmt.Errorf("invalid rollout percentage %d", entry.Rollout)
+	}
+	if entry.Fuzzy < -1 {
+		return WordOptions{}, fmt.Errorf("invalid edit distance %d", entry.Fuzzy)
+	}
+	for _, exception := range entry.Exceptions {
+		opts.Exceptions = append(opts.Exceptions, strings.ToLower(exception))
+	}
+	return opts, nil
+}
+
+// set sets the option key of the entry from its YAML value, a string or a list of strings
+func (entry *wordListEntry) set(key string, value interface{}) (err error) {
+	list, isList := value.([]string)
+	scalar, _ := value.(string)
+	switch key {
+	case "tags", "exceptions":
+		if !isList {
+			return fmt.Errorf("%s must be a list", key)
+		}
+		if key == "tags" {
+			entry.Tags = list
+		} else {
+			entry.Exceptions = list
+		}
+		return nil
+	case "word", "action", "severity", "category", "language", "weight", "whole_word", "rollout", "fuzzy":
+		if isList {
+			return fmt.Errorf("%s can't be a list", key)
+		}
+	default:
+		return fmt.Errorf("unknown option %q", key)
+	}
+
+	switch key {
+	case "word":
+		entry.Word = scalar
+	case "action":
+		entry.Action = scalar
+	case "severity":
+		entry.Severity = scalar
+	case "category":
+		entry.Category = scalar
+	case "language":
+		entry.Language = scalar
+	case "weight":
+		if entry.Weight, err = strconv.ParseFloat(scalar, 64); err != nil {
+			return fmt.Errorf("invalid weight %q", scalar)
+		}
+	case "whole_word":
+		if entry.WholeWord, err = strconv.ParseBool(scalar); err != nil {
+			return fmt.Errorf("invalid whole_word %q", scalar)
+		}
+	case "rollout":
+		if entry.Rollout, err = strconv.Atoi(scalar); err != nil {
+			return fmt.Errorf("invalid rollout %q", scalar)
+		}
+	case "fuzzy":
+		if entry.Fuzzy, err = strconv.Atoi(scalar); err != nil {
+			return fmt.Errorf("invalid fuzzy %q", scalar)
+		}
+	}
+	return nil
+}
+
+// parseWordListYAML parses the subset of YAML word lists are written in
+func parseWordListYAML(data []byte) (list wordListFile, err error) {
+	var entry *wordListEntry
+	inWords := false
+	itemIndent := -1   //Indentation of the dashes starting each word
+	listKey := ""      //Option whose value is a block list being read
+	var items []string //Items of that list so far
+
+	//Sets the block list being read, if any, once a line not belonging to it is reached
+	endList := func() error {
+		if listKey == "" {
+			return nil
+		}
+		key := listKey
+		listKey = ""
+		return entry.set(key, items)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := stripYAMLComment(scanner.Text())
+		content := strings.TrimLeft(text, " ")
+		indent := len(text) - len(content)
+		content = strings.TrimRight(content, " \t")
+		if content == "" || content == "---" {
+			continue
+		}
+		if strings.HasPrefix(content, "\t") {
+			return list, fmt.Errorf("line %d: tabs can't be used for indentation", line)
+		}
+
+		switch {
+		case indent == 0 && !strings.HasPrefix(content, "-"):
+			if err = endList(); err != nil {
+				return list, fmt.Errorf("line %d: %v", line-1, err)
+			}
+			key, value, err := splitYAMLPair(content)
+			if err != nil {
+				return list, fmt.Errorf("line %d: %v", line, err)
+			}
+			if key != "words" {
+				return list, fmt.Errorf("line %d: unknown key %q", line, key)
+			}
+			if value != "" && value != "[]" {
+				return list, fmt.Errorf("line %d: words must be a list of words", line)
+			}
+			inWords = true
+			continue
+		case !inWords:
+			return list, fmt.Errorf("line %d: expected words", line)
+		}
+
+		if listKey != "" && indent > itemIndent && strings.HasPrefix(content, "-") {
+			value, err := parseYAMLScalar(strings.TrimSpace(content[1:]))
+			if err != nil {
+				return list, fmt.Errorf("line %d: %v", line, err)
+			}
+			items = append(items, value)
+			continue
+		}
+		if err = endList(); err != nil {
+			return list, fmt.Errorf("line %d: %v", line-1, err)
+		}
+
+		if content == "-" || strings.HasPrefix(content, "- ") {
+			if itemIndent < 0 {
+				itemIndent = indent
+			}
+			if indent != itemIndent {
+				return list, fmt.Errorf("line %d: inconsistent indentation", line)
+			}
+			list.Words = append(list.Words, wordListEntry{})
+			entry = &list.Words[len(list.Words)-1]
+			content = strings.TrimSpace(content[1:])
+			if content == "" {
+				continue
+			}
+		} else if entry == nil || indent <= itemIndent {
+			return list, fmt.Errorf("line %d: expected a word starting with -", line)
+		}
+
+		key, value, err := splitYAMLPair(content)
+		if err != nil {
+			return list, fmt.Errorf("line %d: %v", line, err)
+		}
+		if value == "" {
+			listKey, items = key, nil
+			continue
+		}
+		var parsed interface{}
+		if strings.HasPrefix(value, "[") {
+			parsed, err = parseYAMLFlowList(value)
+		} else {
+			parsed, err = parseYAMLScalar(value)
+		}
+		if err == nil {
+			err = entry.set(key, parsed)
+		}
+		if err != nil {
+			return list, fmt.Errorf("line %d: %v", line, err)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return list, err
+	}
+	if err = endList(); err != nil {
+		return list, err
+	}
+	return list, nil
+}
+
+// stripYAMLComment removes a comment, starting with # at the start of the line or after a space, outside of quotes
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitYAMLPair splits a key: value pair
+func splitYAMLPair(content string) (key, value string, err error) {
+	i := strings.Index(content+" ", ": ")
+	if i <= 0 {
+		return "", "", fmt.Errorf("expected key: value, got %q", content)
+	}
+	return content[:i], strings.TrimSpace(content[i+1:]), nil
+}
+
+// parseYAMLFlowList parses a list written as [a, b, c]
+func parseYAMLFlowList(value string) (items []string, err error) {
+	if !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("unterminated list %s", value)
+	}
+	value = strings.TrimSpace(value[1 : len(value)-1])
+	for value != "" {
+		var item string
+		if value[0] == '"' || value[0] == '\'' {
+			item = yamlQuotedPrefix(value)
+		} else if i := strings.IndexByte(value, ','); i >= 0 {
+			item = value[:i]
+		} else {
+			item = value
+		}
+		parsed, err := parseYAMLScalar(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, parsed)
+
+		value = strings.TrimSpace(value[len(item):])
+		if value != "" {
+			if value[0] != ',' {
+				return nil, fmt.Errorf("expected , in list, got %q", value)
+			}
+			value = strings.TrimSpace(value[1:])
+		}
+	}
+	return items, nil
+}
+
+// parseYAMLScalar parses a plain, single-quoted or double-quoted scalar
+func parseYAMLScalar(value string) (string, error) {
+	switch {
+	case value == "":
+		return "", fmt.Errorf("empty value")
+	case value[0] == '"':
+		unquoted, ok := unquoteYAML(value)
+		if !ok {
+			return "", fmt.Errorf("invalid quoted value %s", value)
+		}
+		return unquoted, nil
+	case value[0] == '\'':
+		inner := value[1:]
+		if !strings.HasSuffix(inner, "'") || strings.Contains(strings.ReplaceAll(inner[:len(inner)-1], "''", ""), "'") {
+			return "", fmt.Errorf("invalid quoted value %s", value)
+		}
+		return strings.ReplaceAll(inner[:len(inner)-1], "''", "'"), nil
+	case strings.ContainsAny(value[:1], "[]{}&*!|>%@`"):
+		return "", fmt.Errorf("unsupported value %s", value)
+	}
+	return value, nil
+}
+
+// yamlEscapes are the runes YAML's single-character escapes in double-quoted scalars stand for
+var yamlEscapes = map[byte]rune{
+	'0': 0, 'a': '\a', 'b': '\b', 't': '\t', '\t': '\t', 'n': '\n', 'v': '\v', 'f': '\f', 'r': '\r', 'e': 0x1b,
+	' ': ' ', '"': '"', '/': '/', '\\': '\\', 'N': '\u0085', '_': '\u00a0', 'L': '\u2028', 'P': '\u2029',
+}
+
+// yamlHexEscapes are how many hexadecimal digits follow each of YAML's code point escapes
+var yamlHexEscapes = map[byte]int{'x': 2, 'u': 4, 'U': 8}
+
+// unquoteYAML returns the string a YAML double-quoted scalar on a single line stands for, and false if it isn't one
+func unquoteYAML(value string) (string, bool) {
+	if len(value) < 2 || value[0] != '"' || !strings.HasSuffix(value, `"`) || quotedPrefix(value) != value {
+		return "", false
+	}
+	value = value[1 : len(value)-1]
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i++; i == len(value) {
+			return "", false
+		}
+		if r, ok := yamlEscapes[value[i]]; ok {
+			b.WriteRune(r)
+			continue
+		}
+		digits, ok := yamlHexEscapes[value[i]]
+		if !ok || i+digits >= len(value) {
+			return "", false
+		}
+		code, err := strconv.ParseUint(value[i+1:i+1+digits], 16, 32)
+		if err != nil || code > unicode.MaxRune {
+			return "", false
+		}
+		b.WriteRune(rune(code))
+		i += digits
+	}
+	return b.String(), true
+}
+
+// yamlQuotedPrefix returns the quoted string at the start of value, up to and including its closing quote
+func yamlQuotedPrefix(value string) string {
+	if value[0] == '"' {
+		return quotedPrefix(value)
+	}
+	for i := 1; i < len(value); i++ {
+		if value[i] == '\'' {
+			if i+1 < len(value) && value[i+1] == '\'' {
+				i++
+				continue
+			}
+			return value[:i+1]
+		}
+	}
+	return value
+}
+
+// writeWordListYAML writes list in the subset of YAML parseWordListYAML reads
+func writeWordListYAML(w io.Writer, list wordListFile) error {
+	buf := bufio.NewWriter(w)
+	if len(list.Words) == 0 {
+		buf.WriteString("words: []\n")
+		return buf.Flush()
+	}
+
+	buf.WriteString("words:\n")
+	for _, entry := range list.Words {
+		fmt.Fprintf(buf, "  - word: %s\n", yamlScalar(entry.Word))
+		for _, pair := range []struct{ key, value string }{
+			{"action", entry.Action},
+			{"severity", entry.Severity},
+			{"category", entry.Category},
+			{"language", entry.Language},
+		} {
+			if pair.value != "" {
+				fmt.Fprintf(buf, "    %s: %s\n", pair.key, yamlScalar(pair.value))
+			}
+		}
+		if entry.Weight != 0 {
+			fmt.Fprintf(buf, "    weight: %s\n", strconv.FormatFloat(entry.Weight, 'g', -1, 64))
+		}
+		if entry.WholeWord {
+			buf.WriteString("    whole_word: true\n")
+		}
+		if entry.Rollout != 0 {
+			fmt.Fprintf(buf, "    rollout: %d\n", entry.Rollout)
+		}
+		if entry.Fuzzy != 0 {
+			fmt.Fprintf(buf, "    fuzzy: %d\n", entry.Fuzzy)
+		}
+		for _, pair := range []struct {
+			key   string
+			items []string
+		}{{"tags", entry.Tags}, {"exceptions", entry.Exceptions}} {
+			if len(pair.items) == 0 {
+				continue
+			}
+			items := make([]string, len(pair.items))
+			for i, item := range pair.items {
+				items[i] = yamlScalar(item)
+			}
+			fmt.Fprintf(buf, "    %s: [%s]\n", pair.key, strings.Join(items, ", "))
+		}
+	}
+	return buf.Flush()
+}
+
+// yamlScalar returns s as a plain scalar if it can be read back as the same string by any YAML parser, and
+// double-quoted otherwise
+func yamlScalar(s string) string {
+	switch strings.ToLower(s) {
+	case "", "~", "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	for i, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && (i == 0 || !strings.ContainsRune("-_.'", r)) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,208 @@
+package swearfilter
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testWordListYAML = `# Core list
+words:
+  - word: Fuck
+    severity: severe
+    category: profanity
+    language: en
+    tags: [core, en]
+  - word: ass
+    exceptions:
+      - Assign
+      - "assess" # quoted
+    whole_word: true
+  - word: 'kill yourself'
+    action: block
+    weight: 5
+    rollout: 50
+    fuzzy: -1
+`
+
+func TestLoadWordListFromReader(t *testing.T) {
+	expected := map[string]WordOptions{
+		"fuck":          {Severity: SeveritySevere, Category: "profanity", Language: "en", Tags: []string{"core", "en"}},
+		"ass":           {Exceptions: []string{"assign", "assess"}, RequireWordBoundaries: true},
+		"kill yourself": {Action: ActionBlock, Weight: 5, RolloutPercent: 50, MaxEditDistance: -1},
+	}
+	json := `{"words": [
+		{"word": "Fuck", "severity": "severe", "category": "profanity", "language": "en", "tags": ["core", "en"]},
+		{"word": "ass", "exceptions": ["Assign", "assess"], "whole_word": true},
+		{"word": "kill yourself", "action": "block", "weight": 5, "rollout": 50, "fuzzy": -1}
+	]}`
+
+	for name, input := range map[string]string{"yaml": testWordListYAML, "json": json} {
+		t.Run(name, func(t *testing.T) {
+			filter := NewSwearFilter(false, "shit")
+			if err := filter.LoadWordListFromReader(strings.NewReader(input)); err != nil {
+				t.Fatalf("LoadWordListFromReader failed: %v", err)
+			}
+			if len(filter.Words()) != len(expected) {
+				t.Errorf("got words %v, want the previous list replaced", filter.Words())
+			}
+			for word, want := range expected {
+				if got, ok := filter.Options(word); !ok || !reflect.DeepEqual(got, want) {
+					t.Errorf("got options %+v for %q, want %+v", got, word, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadWordListErrors(t *testing.T) {
+	tests := []string{
+		"words:\n  - word: fuck\n    severity: extreme\n",
+		"words:\n  - word: fuck\n    color: red\n",
+		"words:\n  - word: fuck\n    tags: core\n",
+		"words:\n  - word: fuck\n    whole_word: maybe\n",
+		"words:\n  - word: \"fuck\n",
+		"words:\n  - severity: mild\n",
+		"word: fuck\n",
+		"words:\n  - word: f*ck\n",
+		`{"words": [{"word": "fuck", "action": "ban"}]}`,
+		`{"words": [{"word": "fuck", "lang": "en"}]}`,
+		`{"words": [{"word": "fuck", "rollout": 150}]}`,
+	}
+	for _, input := range tests {
+		filter := NewSwearFilter(false, "shit")
+		if err := filter.LoadWordListFromReader(strings.NewReader(input)); err == nil {
+			t.Errorf("LoadWordListFromReader(%q) succeeded, want an error", input)
+		}
+		if words := filter.Words(); len(words) != 1 || words[0] != "shit" {
+			t.Errorf("got words %v after a failed load, want them unchanged", words)
+		}
+	}
+}
+
+func TestSaveWordList(t *testing.T) {
+	filter := NewSwearFilter(false)
+	filter.AddWithOptions(WordOptions{Severity: SeverityMild, Language: "en", Tags: []string{"core", "needs: quotes"}}, "crap")
+	filter.AddWithOptions(WordOptions{Action: ActionShadow, Weight: 0.5, RequireWordBoundaries: true}, "yes")
+	filter.AddWithOptions(WordOptions{Exceptions: []string{"o'clock"}, RolloutPercent: 10, MaxEditDistance: 2}, "69")
+
+	for _, name := range []string{"words.json", "words.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := filter.SaveWordList(path); err != nil {
+				t.Fatalf("SaveWordList failed: %v", err)
+			}
+			loaded := NewSwearFilter(false)
+			if err := loaded.LoadWordList(path); err != nil {
+				t.Fatalf("LoadWordList failed: %v", err)
+			}
+			for _, word := range filter.Words() {
+				want, _ := filter.Options(word)
+				if got, ok := loaded.Options(word); !ok || !reflect.DeepEqual(got, want) {
+					t.Errorf("got options %+v for %q, want %+v", got, word, want)
+				}
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := filter.WriteWordList(&buf, WordListYAML); err != nil {
+		t.Fatalf("WriteWordList failed: %v", err)
+	}
+	expected := `words:
+  - word: "69"
+    rollout: 10
+    fuzzy: 2
+    exceptions: [o'clock]
+  - word: crap
+    severity: mild
+    language: en
+    tags: [core, "needs: quotes"]
+  - word: "yes"
+    action: shadow
+    weight: 0.5
+    whole_word: true
+`
+	if buf.String() != expected {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
+
+func TestSaveWordListShadow(t *testing.T) {
+	filter := NewSwearFilter(false)
+	filter.AddWithOptions(WordOptions{Shadow: true, Category: "profanity"}, "darn")
+
+	var buf bytes.Buffer
+	if err := filter.WriteWordList(&buf, WordListYAML); err != nil {
+		t.Fatalf("WriteWordList failed: %v", err)
+	}
+	loaded := NewSwearFilter(false)
+	if err := loaded.LoadWordListFromReader(&buf); err != nil {
+		t.Fatalf("LoadWordListFromReader failed: %v", err)
+	}
+	if trippers, _ := loaded.Check("darn"); len(trippers) != 0 {
+		t.Errorf("got trippers %v, want the word still monitor-only", trippers)
+	}
+	if got, _ := loaded.Options("darn"); got.Action != ActionShadow || got.Category != "profanity" {
+		t.Errorf("got options %+v, want the shadow action", got)
+	}
+}
+
+func TestParseYAMLScalar(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plain", "plain"},
+		{"o'clock", "o'clock"},
+		{"'it''s'", "it's"},
+		{"''''", "'"},
+		{`"it's"`, "it's"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"a\/b"`, "a/b"},
+		{`"tab\there"`, "tab\there"},
+		{`"\x41é\U0001F595"`, "Aé\U0001F595"},
+		{`"\e\0\_\N"`, "\x1b\x00\u00a0\u0085"},
+	}
+	for _, tt := range tests {
+		if got, err := parseYAMLScalar(tt.input); err != nil || got != tt.expected {
+			t.Errorf("parseYAMLScalar(%s) got %q, %v, want %q", tt.input, got, err, tt.expected)
+		}
+	}
+
+	for _, input := range []string{`"\x"`, `"\x4"`, `"\q"`, `"\101"`, `"\'"`, `"a\"`, `"a`, "'a", "'a'b'", ""} {
+		if got, err := parseYAMLScalar(input); err == nil {
+			t.Errorf("parseYAMLScalar(%s) got %q, want an error", input, got)
+		}
+	}
+}
+
+func TestLoadWordListConcurrent(t *testing.T) {
+	filter := NewSwearFilter(false)
+	lists := []string{"words:\n  - word: fuck\n  - word: shit\n", "words:\n  - word: crap\n"}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if err := filter.LoadWordListFromReader(strings.NewReader(lists[i%2])); err != nil {
+				t.Errorf("LoadWordListFromReader failed: %v", err)
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		//Either list may be loaded, but never a mix of both
+		tripped, err := filter.Check("fuck this shit and crap")
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		if len(tripped) == 3 || (len(tripped) == 1 && tripped[0] != "crap") {
+			t.Errorf("got %v, want the words of one list", tripped)
+		}
+	}
+	wg.Wait()
+}